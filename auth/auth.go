@@ -0,0 +1,42 @@
+// Package auth 验证玩家的登录信息，给玩家一个稳定的身份，断线重连和下次登录时都不变
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/CuteReimu/uno-server/config"
+)
+
+// ErrLoginFailed 用户名或者凭证不正确
+var ErrLoginFailed = errors.New("用户名或者凭证不正确")
+
+// User 登录成功的玩家
+type User struct {
+	Id     string // 稳定的玩家ID
+	Name   string // 显示的名字
+	Avatar uint32 // 头像ID，为0则使用玩家登录时自己选择的头像
+}
+
+// Authenticator 验证玩家的登录信息
+type Authenticator interface {
+	// Authenticate 验证成功则返回玩家的身份，否则返回 ErrLoginFailed 或者其它错误
+	Authenticate(username, token string) (User, error)
+}
+
+// New 按配置auth.type创建验证器。为none时不验证，返回nil
+func New() (Authenticator, error) {
+	switch t := config.GlobalConfig.GetString("auth.type"); t {
+	case "", "none":
+		return nil, nil
+	case "static":
+		return LoadStatic(config.GlobalConfig.GetString("auth.static.file"))
+	case "hmac":
+		secret := config.GlobalConfig.GetString("auth.hmac.secret")
+		if secret == "" {
+			return nil, errors.New("没有配置auth.hmac.secret")
+		}
+		return NewHmac([]byte(secret)), nil
+	default:
+		return nil, fmt.Errorf("不支持的验证方式：%s", t)
+	}
+}
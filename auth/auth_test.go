@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHmac(t *testing.T) {
+	h := NewHmac([]byte("secret"))
+	valid := h.Token("alice", time.Now().Add(time.Hour))
+	expiresStr, signature, _ := strings.Cut(valid, ":")
+	tests := []struct {
+		name     string
+		username string
+		token    string
+		ok       bool
+	}{
+		{"有效的凭证", "alice", valid, true},
+		{"永不过期", "alice", h.Token("alice", time.Time{}), true},
+		{"已经过期", "alice", h.Token("alice", time.Now().Add(-time.Second)), false},
+		{"别人的凭证", "bob", valid, false},
+		{"改了过期时间", "alice", "9" + expiresStr + ":" + signature, false},
+		{"改了签名", "alice", expiresStr + ":" + strings.Repeat("0", len(signature)), false},
+		{"其它密钥签发的凭证", "alice", NewHmac([]byte("other")).Token("alice", time.Time{}), false},
+		{"没有过期时间", "alice", signature, false},
+		{"过期时间不是数字", "alice", "abc:" + signature, false},
+		{"用户名为空", "", h.Token("", time.Time{}), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := h.Authenticate(tt.username, tt.token)
+			if !tt.ok {
+				if !errors.Is(err, ErrLoginFailed) {
+					t.Errorf("应该登录失败，实际是%v", err)
+				}
+				return
+			}
+			if err != nil || user.Id != tt.username {
+				t.Errorf("应该登录成功，user=%+v err=%v", user, err)
+			}
+		})
+	}
+}
+
+func TestStatic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	data := `[{"username": "alice", "token": "123456", "name": "爱丽丝", "avatar": 3}, {"username": "bob", "token": "abc"}]`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := LoadStatic(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		username string
+		token    string
+		want     User
+		ok       bool
+	}{
+		{"alice", "123456", User{Id: "alice", Name: "爱丽丝", Avatar: 3}, true},
+		{"bob", "abc", User{Id: "bob", Name: "bob"}, true},
+		{"alice", "12345", User{}, false},
+		{"alice", "", User{}, false},
+		{"carol", "123456", User{}, false},
+	}
+	for _, tt := range tests {
+		user, err := s.Authenticate(tt.username, tt.token)
+		if tt.ok && (err != nil || user != tt.want) {
+			t.Errorf("Authenticate(%q, %q) = %+v, %v，应该是%+v", tt.username, tt.token, user, err, tt.want)
+		}
+		if !tt.ok && !errors.Is(err, ErrLoginFailed) {
+			t.Errorf("Authenticate(%q, %q)应该登录失败，实际是%v", tt.username, tt.token, err)
+		}
+	}
+
+	for _, bad := range []string{`not json`, `[{"username": ""}]`, `[{"username": "a"}, {"username": "a"}]`} {
+		if err := os.WriteFile(path, []byte(bad), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadStatic(path); err == nil {
+			t.Errorf("用户文件%s应该报错", bad)
+		}
+	}
+}
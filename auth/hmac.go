@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Hmac 用密钥签名的凭证，不需要保存用户列表。凭证的格式为 <过期时间>:<签名>，
+// 过期时间是Unix秒数，为0表示永不过期，签名是对 <用户名>:<过期时间> 的HMAC-SHA256
+type Hmac struct {
+	secret []byte
+}
+
+func NewHmac(secret []byte) *Hmac {
+	return &Hmac{secret: secret}
+}
+
+// Token 给username签发一个凭证，expires为零值表示永不过期
+func (h *Hmac) Token(username string, expires time.Time) string {
+	var expiresUnix int64
+	if !expires.IsZero() {
+		expiresUnix = expires.Unix()
+	}
+	return strconv.FormatInt(expiresUnix, 10) + ":" + h.sign(username, expiresUnix)
+}
+
+func (h *Hmac) sign(username string, expires int64) string {
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write([]byte(username + ":" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (h *Hmac) Authenticate(username, token string) (User, error) {
+	expiresStr, signature, ok := strings.Cut(token, ":")
+	if !ok || username == "" {
+		return User{}, ErrLoginFailed
+	}
+	expires, err := strconv.ParseInt(expiresStr, 10, 64)
+	if err != nil {
+		return User{}, ErrLoginFailed
+	}
+	if !hmac.Equal([]byte(signature), []byte(h.sign(username, expires))) {
+		return User{}, ErrLoginFailed
+	}
+	if expires != 0 && time.Now().Unix() >= expires {
+		return User{}, ErrLoginFailed
+	}
+	return User{Id: username, Name: username}, nil
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// staticUser 用户文件中的一个玩家
+type staticUser struct {
+	Username string `json:"username"`
+	Token    string `json:"token"`
+	Name     string `json:"name"`   // 显示的名字，为空则使用用户名
+	Avatar   uint32 `json:"avatar"` // 头像ID，为0则使用玩家登录时自己选择的头像
+}
+
+// Static 从本地文件读取的固定的用户列表
+type Static struct {
+	users map[string]staticUser
+}
+
+// LoadStatic 读取用户文件，文件内容是一个JSON数组，例如：
+//
+//	[{"username": "alice", "token": "123456", "name": "爱丽丝", "avatar": 3}]
+func LoadStatic(path string) (*Static, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var users []staticUser
+	if err = json.Unmarshal(data, &users); err != nil {
+		return nil, fmt.Errorf("用户文件%s格式错误：%w", path, err)
+	}
+	s := &Static{users: make(map[string]staticUser, len(users))}
+	for _, user := range users {
+		if user.Username == "" {
+			return nil, fmt.Errorf("用户文件%s中有用户名为空的用户", path)
+		}
+		if _, ok := s.users[user.Username]; ok {
+			return nil, fmt.Errorf("用户文件%s中的用户名重复：%s", path, user.Username)
+		}
+		s.users[user.Username] = user
+	}
+	return s, nil
+}
+
+func (s *Static) Authenticate(username, token string) (User, error) {
+	user, ok := s.users[username]
+	if !ok || subtle.ConstantTimeCompare([]byte(user.Token), []byte(token)) != 1 {
+		return User{}, ErrLoginFailed
+	}
+	name := user.Name
+	if name == "" {
+		name = username
+	}
+	return User{Id: username, Name: name, Avatar: user.Avatar}, nil
+}
@@ -0,0 +1,105 @@
+// uno-replay 在终端中回放一局游戏的记录。
+// 参数可以是记录文件的路径，也可以是回放ID（在当前目录下config.yaml中配置的目录里查找）
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/CuteReimu/uno-server/game"
+	"github.com/CuteReimu/uno-server/utils"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	speed := flag.Float64("speed", 0, "播放速度，1为原速，为0则不等待，直接输出所有事件")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "用法：uno-replay [-speed 倍速] <记录文件或者回放ID>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	utils.SetLogLevel(slog.LevelError)
+
+	path := flag.Arg(0)
+	if _, err := os.Stat(path); err != nil {
+		var ok bool
+		if path, ok = game.RecordPath(path); !ok {
+			fmt.Fprintln(os.Stderr, "回放ID不合法：", flag.Arg(0))
+			os.Exit(1)
+		}
+	}
+	record, err := game.LoadRecord(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "读取记录失败：", err)
+		os.Exit(1)
+	}
+	fmt.Printf("回放%s，第%d局，%d人，随机数种子：%d，开始于%s\n", record.Id, record.Round, record.PlayerCount, record.Seed, record.StartTime.Format(time.DateTime))
+	for location, name := range record.Players {
+		if location < len(record.Identities) {
+			name += "，" + record.Identities[location].Name
+		}
+		fmt.Printf("%d号座位：%s\n", location, name)
+	}
+	fmt.Printf("房规：%+v\n", record.Rules)
+	var last int64
+	for _, e := range record.Events {
+		if *speed > 0 {
+			time.Sleep(time.Duration(float64(e.Time-last) / *speed * float64(time.Millisecond)))
+			last = e.Time
+		}
+		fmt.Printf("[%s] %s\n", (time.Duration(e.Time) * time.Millisecond).String(), describe(e))
+	}
+}
+
+func describe(e game.Event) string {
+	cards := make([]string, 0, len(e.Cards))
+	for _, card := range e.Cards {
+		cards = append(cards, card.String())
+	}
+	switch e.Type {
+	case game.EventDeal:
+		return fmt.Sprintf("%d号玩家的初始手牌：%s", e.Player, strings.Join(cards, " "))
+	case game.EventFlip:
+		return fmt.Sprintf("翻出了%s", strings.Join(cards, " "))
+	case game.EventDraw:
+		return fmt.Sprintf("%d号玩家摸了%d张牌：%s", e.Player, len(cards), strings.Join(cards, " "))
+	case game.EventDiscard:
+		s := fmt.Sprintf("%d号玩家打出%s", e.Player, strings.Join(cards, " "))
+		if e.WantColor != game.ColorBlack {
+			s += "，并选择" + e.WantColor.String()
+		}
+		return s
+	case game.EventTurn:
+		return fmt.Sprintf("轮到%d号玩家", e.Player)
+	case game.EventPass:
+		return fmt.Sprintf("%d号玩家摸牌后选择不出", e.Player)
+	case game.EventUnoCall:
+		return fmt.Sprintf("%d号玩家喊了UNO", e.Player)
+	case game.EventUnoCatch:
+		return fmt.Sprintf("%d号玩家抓到%d号玩家忘记喊UNO", e.Player, e.Target)
+	case game.EventChallenge:
+		if e.Success {
+			return fmt.Sprintf("%d号玩家质疑%d号玩家打出的+4，质疑成功", e.Player, e.Target)
+		}
+		return fmt.Sprintf("%d号玩家质疑%d号玩家打出的+4，质疑失败", e.Player, e.Target)
+	case game.EventSwap:
+		return fmt.Sprintf("%d号玩家和%d号玩家交换手牌", e.Player, e.Target)
+	case game.EventRotate:
+		return "所有玩家把手牌传给下一个玩家"
+	case game.EventRoundOver:
+		return fmt.Sprintf("%d号玩家获胜，得到%d分", e.Player, e.Count)
+	case game.EventAbort:
+		return "房间解散，这一局没有打完"
+	case game.EventChat:
+		return fmt.Sprintf("%d号玩家说：%s", e.Player, e.Text)
+	case game.EventEmote:
+		return fmt.Sprintf("%d号玩家发了表情：%s", e.Player, e.Text)
+	}
+	return e.Type
+}
@@ -0,0 +1,53 @@
+// uno-sim 用虚拟时钟快速进行大量只有机器人的对局，统计各种机器人策略的胜率。
+// 房规使用当前目录下config.yaml中的配置
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/CuteReimu/uno-server/game"
+	"github.com/CuteReimu/uno-server/sim"
+	"github.com/CuteReimu/uno-server/utils"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	games := flag.Int("games", 1000, "对局数")
+	strategies := flag.String("strategies", "hard,normal,easy", "参加模拟的机器人策略，用逗号分隔，每个策略占一个座位")
+	parallel := flag.Int("parallel", 0, "同时进行的对局数，为0则使用CPU的核数")
+	maxMoves := flag.Int("max-moves", 5000, "每局最多的行动次数，超过了就算这局没有结束，为0表示不限制")
+	seed := flag.Int64("seed", 0, "随机数种子，为0则随机生成")
+	flag.Parse()
+
+	utils.SetLogLevel(slog.LevelError)
+	if err := game.LoadDeckSpec(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	start := time.Now()
+	result, err := sim.Run(sim.Config{
+		Games:      *games,
+		Strategies: strings.Split(*strategies, ","),
+		Rules:      game.DefaultRules(),
+		Parallel:   *parallel,
+		MaxMoves:   *maxMoves,
+		Seed:       *seed,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("完成%d局，未结束%d局，用时%s，随机数种子：%d\n", result.Games, result.Unfinished, time.Since(start).Round(time.Millisecond), result.Seed)
+	fmt.Printf("%-10s %8s %8s %18s %10s\n", "策略", "胜场", "胜率", "95%置信区间", "场均得分")
+	for _, e := range result.Entrants {
+		avgPoints := 0.0
+		if e.Wins > 0 {
+			avgPoints = float64(e.Points) / float64(e.Wins)
+		}
+		fmt.Printf("%-10s %8d %7.1f%% %8.1f%% ~ %5.1f%% %10.1f\n", e.Strategy, e.Wins, e.WinRate*100, e.WinRateLow*100, e.WinRateHigh*100, avgPoints)
+	}
+	fmt.Printf("平均每局%.1f次行动（95%%置信区间%.1f ~ %.1f），虚拟时长%s\n", result.AvgMoves, result.AvgMovesLow, result.AvgMovesHigh, result.AvgDuration.Round(time.Second))
+}
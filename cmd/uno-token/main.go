@@ -0,0 +1,34 @@
+// uno-token 用当前目录下config.yaml中配置的auth.hmac.secret给玩家签发登录凭证
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/CuteReimu/uno-server/auth"
+	"github.com/CuteReimu/uno-server/config"
+	"os"
+	"time"
+)
+
+func main() {
+	valid := flag.Duration("valid", 0, "凭证的有效期，例如720h，为0则永不过期")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "用法：uno-token [-valid 有效期] <用户名>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	secret := config.GlobalConfig.GetString("auth.hmac.secret")
+	if secret == "" {
+		fmt.Fprintln(os.Stderr, "没有配置auth.hmac.secret")
+		os.Exit(1)
+	}
+	var expires time.Time
+	if *valid > 0 {
+		expires = time.Now().Add(*valid)
+	}
+	fmt.Println(auth.NewHmac([]byte(secret)).Token(flag.Arg(0), expires))
+}
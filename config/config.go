@@ -1,9 +1,11 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"github.com/spf13/viper"
 	"log/slog"
+	"testing"
 )
 
 var GlobalConfig *viper.Viper
@@ -15,6 +17,10 @@ func init() {
 	GlobalConfig.AddConfigPath(".")
 	err := GlobalConfig.ReadInConfig()
 	if err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if errors.As(err, &notFound) && testing.Testing() {
+			return // go test在包的目录下运行，由各个包的TestMain读取配置
+		}
 		slog.Error("unable to write logs", "error", err)
 		panic(fmt.Sprintf("unable to write logs, error: %+v", err))
 	}
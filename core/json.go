@@ -0,0 +1,177 @@
+package core
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"github.com/davyxu/cellnet"
+	"github.com/davyxu/cellnet/proc"
+	"github.com/davyxu/cellnet/proc/gorillaws"
+	"github.com/davyxu/cellnet/proc/tcp"
+	"github.com/davyxu/cellnet/util"
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"net"
+)
+
+// JSON编码和protobuf编码使用同样的消息ID，字段名和uno.proto中的一样
+var (
+	jsonMarshal   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	jsonUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+func init() {
+	// tcp连接：封包格式和tcp.ltv一样，只是包体是JSON
+	proc.RegisterProcessor("tcp.json", func(bundle proc.ProcessorBundle, userCallback cellnet.EventCallback) {
+		bundle.SetTransmitter(new(tcpJsonTransmitter))
+		bundle.SetHooker(new(tcp.MsgHooker))
+		bundle.SetCallback(proc.NewQueuedEventCallback(userCallback))
+	})
+	// WebSocket连接：每个消息是一个文本帧，内容为 jsonFrame
+	proc.RegisterProcessor("gorillaws.json", func(bundle proc.ProcessorBundle, userCallback cellnet.EventCallback) {
+		bundle.SetTransmitter(new(wsJsonTransmitter))
+		bundle.SetHooker(new(gorillaws.MsgHooker))
+		bundle.SetCallback(proc.NewQueuedEventCallback(userCallback))
+	})
+}
+
+// ProcessorName 根据编码方式返回侦听器使用的处理器名称，transport为tcp或gorillaws，codec为protobuf或json
+func ProcessorName(transport, codec string) (string, bool) {
+	switch codec {
+	case "", "protobuf":
+		return transport + ".ltv", true
+	case "json":
+		return transport + ".json", true
+	}
+	return "", false
+}
+
+// jsonFrame WebSocket文本帧的内容。客户端发来的消息可以只填id或者name，name是uno.proto中的消息名
+type jsonFrame struct {
+	Id   int             `json:"id"`
+	Name string          `json:"name,omitempty"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+func encodeJson(msg interface{}) (int, []byte, error) {
+	meta := cellnet.MessageMetaByMsg(msg)
+	if meta == nil {
+		return 0, nil, cellnet.NewErrorContext("msg not exists", msg)
+	}
+	data, err := jsonMarshal.Marshal(msg.(proto.Message))
+	return meta.ID, data, err
+}
+
+func decodeJson(msgId int, data []byte) (interface{}, error) {
+	meta := cellnet.MessageMetaByID(msgId)
+	if meta == nil {
+		return nil, cellnet.NewErrorContext("msg not exists", msgId)
+	}
+	msg := meta.NewType()
+	if len(data) == 0 {
+		return msg, nil
+	}
+	return msg, jsonUnmarshal.Unmarshal(data, msg.(proto.Message))
+}
+
+// socketOpt tcp侦听器的读写设置
+type socketOpt interface {
+	MaxPacketSize() int
+	ApplySocketReadTimeout(conn net.Conn, callback func())
+	ApplySocketWriteTimeout(conn net.Conn, callback func())
+}
+
+type tcpJsonTransmitter struct {
+}
+
+func (tcpJsonTransmitter) OnRecvMessage(ses cellnet.Session) (msg interface{}, err error) {
+	conn, ok := ses.Raw().(net.Conn)
+	if !ok || conn == nil {
+		return nil, nil
+	}
+	opt := ses.Peer().(socketOpt)
+	opt.ApplySocketReadTimeout(conn, func() {
+		var header [4]byte
+		if _, err = io.ReadFull(conn, header[:2]); err != nil {
+			return
+		}
+		size := binary.LittleEndian.Uint16(header[:])
+		if opt.MaxPacketSize() > 0 && int(size) >= opt.MaxPacketSize() {
+			err = util.ErrMaxPacket
+			return
+		}
+		if size < 2 {
+			err = util.ErrShortMsgID
+			return
+		}
+		body := make([]byte, size)
+		if _, err = io.ReadFull(conn, body); err != nil {
+			return
+		}
+		msg, err = decodeJson(int(binary.LittleEndian.Uint16(body)), body[2:])
+	})
+	return
+}
+
+func (tcpJsonTransmitter) OnSendMessage(ses cellnet.Session, msg interface{}) (err error) {
+	conn, ok := ses.Raw().(net.Conn)
+	if !ok || conn == nil {
+		return nil
+	}
+	msgId, data, err := encodeJson(msg)
+	if err != nil {
+		return err
+	}
+	pkt := make([]byte, 4+len(data))
+	binary.LittleEndian.PutUint16(pkt, uint16(2+len(data)))
+	binary.LittleEndian.PutUint16(pkt[2:], uint16(msgId))
+	copy(pkt[4:], data)
+	ses.Peer().(socketOpt).ApplySocketWriteTimeout(conn, func() {
+		err = util.WriteFull(conn, pkt)
+	})
+	return
+}
+
+type wsJsonTransmitter struct {
+}
+
+func (wsJsonTransmitter) OnRecvMessage(ses cellnet.Session) (msg interface{}, err error) {
+	conn, ok := ses.Raw().(*websocket.Conn)
+	if !ok || conn == nil {
+		return nil, nil
+	}
+	messageType, raw, err := conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	if messageType != websocket.TextMessage {
+		return nil, errors.New("JSON编码的连接只接受文本帧")
+	}
+	var frame jsonFrame
+	if err = json.Unmarshal(raw, &frame); err != nil {
+		return nil, err
+	}
+	if frame.Id == 0 && frame.Name != "" {
+		frame.Id = int(util.StringHash(frame.Name))
+	}
+	return decodeJson(frame.Id, frame.Data)
+}
+
+func (wsJsonTransmitter) OnSendMessage(ses cellnet.Session, msg interface{}) error {
+	conn, ok := ses.Raw().(*websocket.Conn)
+	if !ok || conn == nil {
+		return nil
+	}
+	msgId, data, err := encodeJson(msg)
+	if err != nil {
+		return err
+	}
+	name := string(msg.(proto.Message).ProtoReflect().Descriptor().Name())
+	raw, err := json.Marshal(&jsonFrame{Id: msgId, Name: name, Data: data})
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.TextMessage, raw)
+}
@@ -0,0 +1,212 @@
+package core
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"github.com/CuteReimu/uno-server/protos"
+	"github.com/davyxu/cellnet"
+	"github.com/davyxu/cellnet/util"
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestProcessorName(t *testing.T) {
+	tests := []struct {
+		transport, codec string
+		want             string
+		ok               bool
+	}{
+		{"tcp", "", "tcp.ltv", true},
+		{"tcp", "protobuf", "tcp.ltv", true},
+		{"tcp", "json", "tcp.json", true},
+		{"gorillaws", "json", "gorillaws.json", true},
+		{"tcp", "xml", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := ProcessorName(tt.transport, tt.codec); got != tt.want || ok != tt.ok {
+			t.Errorf("ProcessorName(%q, %q) = %q, %v，应该是%q, %v", tt.transport, tt.codec, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestJsonCodec(t *testing.T) {
+	tests := []struct {
+		msg   proto.Message
+		field string // JSON中应该出现的字段名，和uno.proto中的一样
+	}{
+		{&protos.LoginTos{Username: "alice", Token: "123", AvatarId: 3}, `"avatar_id":3`},
+		{&protos.InitToc{PlayerNum: 2, Seed: -1, Players: []*protos.PlayerIdentity{{UserId: "alice", Name: "爱丽丝"}, {Name: "机器人"}}}, `"user_id":"alice"`},
+		{&protos.DiscardCardTos{}, `"target_player_id":0`}, // 零值也要发给客户端
+	}
+	for _, tt := range tests {
+		msgId, data, err := encodeJson(tt.msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msgId != cellnet.MessageMetaByMsg(tt.msg).ID {
+			t.Errorf("%T的消息ID是%d，应该和protobuf编码的一样", tt.msg, msgId)
+		}
+		if !strings.Contains(strings.ReplaceAll(string(data), " ", ""), tt.field) {
+			t.Errorf("%T编码成了%s，应该包含%s", tt.msg, data, tt.field)
+		}
+		msg, err := decodeJson(msgId, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(msg.(proto.Message), tt.msg) {
+			t.Errorf("%T解码后是%v，应该是%v", tt.msg, msg, tt.msg)
+		}
+	}
+
+	loginId := cellnet.MessageMetaByMsg(&protos.LoginTos{}).ID
+	if msg, err := decodeJson(loginId, []byte(`{"username":"bob","unknown":1}`)); err != nil || msg.(*protos.LoginTos).Username != "bob" {
+		t.Errorf("不认识的字段应该忽略，msg=%v err=%v", msg, err)
+	}
+	if msg, err := decodeJson(loginId, nil); err != nil || !proto.Equal(msg.(proto.Message), &protos.LoginTos{}) {
+		t.Errorf("没有消息体时应该解码成空消息，msg=%v err=%v", msg, err)
+	}
+	if _, err := decodeJson(loginId, []byte(`{"username":1}`)); err == nil {
+		t.Error("字段类型不对时应该报错")
+	}
+	if _, err := decodeJson(1, nil); err == nil {
+		t.Error("不存在的消息ID应该报错")
+	}
+}
+
+// testSession 只用来给编解码器提供连接的会话
+type testSession struct {
+	cellnet.Session
+	raw  interface{}
+	peer cellnet.Peer
+}
+
+func (s *testSession) Raw() interface{}   { return s.raw }
+func (s *testSession) Peer() cellnet.Peer { return s.peer }
+
+// testPeer 不设超时的tcp侦听器
+type testPeer struct {
+	cellnet.Peer
+	maxPacketSize int
+}
+
+func (p *testPeer) MaxPacketSize() int                                  { return p.maxPacketSize }
+func (p *testPeer) ApplySocketReadTimeout(_ net.Conn, callback func())  { callback() }
+func (p *testPeer) ApplySocketWriteTimeout(_ net.Conn, callback func()) { callback() }
+
+func TestTcpJson(t *testing.T) {
+	peer := &testPeer{maxPacketSize: 100}
+	tests := []struct {
+		name string
+		pkt  func() []byte
+		err  error
+	}{
+		{"包太大", func() []byte { return binary.LittleEndian.AppendUint16(nil, 100) }, util.ErrMaxPacket},
+		{"没有消息ID", func() []byte { return binary.LittleEndian.AppendUint16(nil, 1) }, util.ErrShortMsgID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer func() { _ = client.Close() }()
+			defer func() { _ = server.Close() }()
+			go func() { _, _ = client.Write(tt.pkt()) }()
+			if _, err := new(tcpJsonTransmitter).OnRecvMessage(&testSession{raw: server, peer: peer}); !errors.Is(err, tt.err) {
+				t.Errorf("OnRecvMessage()的错误是%v，应该是%v", err, tt.err)
+			}
+		})
+	}
+
+	client, server := net.Pipe()
+	defer func() { _ = client.Close() }()
+	defer func() { _ = server.Close() }()
+	want := &protos.LoginTos{Username: "爱丽丝", Token: "123"}
+	errCh := make(chan error, 1)
+	go func() { errCh <- new(tcpJsonTransmitter).OnSendMessage(&testSession{raw: client, peer: peer}, want) }()
+	msg, err := new(tcpJsonTransmitter).OnRecvMessage(&testSession{raw: server, peer: peer})
+	if err != nil || <-errCh != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(msg.(proto.Message), want) {
+		t.Errorf("收到%v，应该是%v", msg, want)
+	}
+}
+
+func TestWsJson(t *testing.T) {
+	received := make(chan interface{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := new(websocket.Upgrader).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		ses := &testSession{raw: conn}
+		for {
+			msg, err := new(wsJsonTransmitter).OnRecvMessage(ses)
+			if err != nil {
+				if _, ok := err.(*websocket.CloseError); ok {
+					return
+				}
+				received <- err
+				continue
+			}
+			received <- msg
+			if err = new(wsJsonTransmitter).OnSendMessage(ses, msg); err != nil {
+				received <- err
+			}
+		}
+	}))
+	defer srv.Close()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	loginId := cellnet.MessageMetaByMsg(&protos.LoginTos{}).ID
+	tests := []struct {
+		name        string
+		messageType int
+		frame       string
+		ok          bool
+	}{
+		{"用id", websocket.TextMessage, `{"id":` + strconv.Itoa(loginId) + `,"data":{"username":"alice"}}`, true},
+		{"只用name", websocket.TextMessage, `{"name":"login_tos","data":{"username":"alice"}}`, true},
+		{"不是JSON", websocket.TextMessage, `login_tos`, false},
+		{"二进制帧", websocket.BinaryMessage, `{"name":"login_tos","data":{"username":"alice"}}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := conn.WriteMessage(tt.messageType, []byte(tt.frame)); err != nil {
+				t.Fatal(err)
+			}
+			got := <-received
+			if _, isErr := got.(error); isErr == tt.ok {
+				t.Fatalf("服务器收到%v", got)
+			}
+			if !tt.ok {
+				return
+			}
+			if !proto.Equal(got.(proto.Message), &protos.LoginTos{Username: "alice"}) {
+				t.Errorf("服务器收到%v", got)
+			}
+			// 服务器原样发回来，帧里要同时有id和name
+			_, raw, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var frame jsonFrame
+			if err = json.Unmarshal(raw, &frame); err != nil {
+				t.Fatal(err)
+			}
+			if frame.Id != loginId || frame.Name != "login_tos" || !strings.Contains(string(frame.Data), `"alice"`) {
+				t.Errorf("客户端收到%s", raw)
+			}
+		})
+	}
+}
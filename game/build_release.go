@@ -0,0 +1,6 @@
+//go:build !debug
+
+package game
+
+// debugBuild 是否是debug版本，用 go build -tags debug 编译。debug版本会把随机数种子发给客户端，方便复现问题
+const debugBuild = false
@@ -1,9 +1,9 @@
 package game
 
 import (
+	"github.com/CuteReimu/uno-server/protos"
 	"math/rand"
 	"strconv"
-	"time"
 )
 
 const (
@@ -34,11 +34,14 @@ func (c Color) String() string {
 
 type ICard interface {
 	Id() uint32
-	CanPlay(game *Game, player IPlayer, args ...uint32) bool
+	// CanPlay 判断这张牌现在能不能打出，不能打出时返回原因
+	CanPlay(game *Game, player IPlayer, args ...uint32) protos.ErrorCode
 	Execute(game *Game, player IPlayer, args ...uint32)
 	String() string
 	Color() Color
 	Number() uint32
+	// Score 一局结束时这张牌还在手里的话，算多少分
+	Score() int
 }
 
 type baseCard struct {
@@ -67,16 +70,32 @@ func newNumberCard(id, color, num uint32) ICard {
 	return &numberCard{colorfulCard{baseCard{id}, Color(color)}, num}
 }
 
-func (c *numberCard) CanPlay(game *Game, _ IPlayer, _ ...uint32) bool {
-	if game.WantColor == ColorBlack {
-		return true
+func (c *numberCard) CanPlay(game *Game, player IPlayer, args ...uint32) protos.ErrorCode {
+	if game.PendingDraw > 0 {
+		return protos.ErrorCode_must_stack_or_draw
+	}
+	if game.WantColor != ColorBlack && game.WantColor != c.Color() && game.LastCard.Number() != c.Number() {
+		return protos.ErrorCode_card_not_match
+	}
+	if game.Rules.SevenZero && c.num == 7 {
+		if len(args) < 2 || int(args[1]) >= game.TotalPlayerCount || int(args[1]) == player.Location() {
+			logger.Error("参数错误")
+			return protos.ErrorCode_invalid_target
+		}
 	}
-	return game.WantColor == c.Color() || game.LastCard.Number() == c.Number()
+	return protos.ErrorCode_success
 }
 
-func (c *numberCard) Execute(game *Game, _ IPlayer, _ ...uint32) {
+func (c *numberCard) Execute(game *Game, player IPlayer, args ...uint32) {
 	game.LastCard = c
 	game.WantColor = c.Color()
+	if game.Rules.SevenZero && len(args) > 0 {
+		if c.num == 7 && len(args) >= 2 {
+			game.swapCards(player, game.Players[args[1]])
+		} else if c.num == 0 {
+			game.passCards()
+		}
+	}
 	game.NextPlayer(1)
 }
 
@@ -84,6 +103,10 @@ func (c *numberCard) Number() uint32 {
 	return c.num
 }
 
+func (c *numberCard) Score() int {
+	return int(c.num)
+}
+
 func (c *numberCard) String() string {
 	return c.Color().String() + strconv.Itoa(int(c.Number()))
 }
@@ -96,11 +119,14 @@ func newSkipCard(id, color uint32) ICard {
 	return &cardSkip{colorfulCard{baseCard{id}, Color(color)}}
 }
 
-func (c *cardSkip) CanPlay(game *Game, _ IPlayer, _ ...uint32) bool {
-	if game.WantColor == ColorBlack {
-		return true
+func (c *cardSkip) CanPlay(game *Game, _ IPlayer, _ ...uint32) protos.ErrorCode {
+	if game.PendingDraw > 0 {
+		return protos.ErrorCode_must_stack_or_draw
 	}
-	return game.WantColor == c.Color() || game.LastCard.Number() == c.Number()
+	if game.WantColor == ColorBlack || game.WantColor == c.Color() || game.LastCard.Number() == c.Number() {
+		return protos.ErrorCode_success
+	}
+	return protos.ErrorCode_card_not_match
 }
 
 func (c *cardSkip) Execute(game *Game, _ IPlayer, _ ...uint32) {
@@ -109,6 +135,10 @@ func (c *cardSkip) Execute(game *Game, _ IPlayer, _ ...uint32) {
 	game.NextPlayer(2)
 }
 
+func (c *cardSkip) Score() int {
+	return 20
+}
+
 func (c *cardSkip) String() string {
 	return c.Color().String() + "跳过"
 }
@@ -125,11 +155,14 @@ func newReverseCard(id, color uint32) ICard {
 	return &cardReverse{colorfulCard{baseCard{id}, Color(color)}}
 }
 
-func (c *cardReverse) CanPlay(game *Game, _ IPlayer, _ ...uint32) bool {
-	if game.WantColor == ColorBlack {
-		return true
+func (c *cardReverse) CanPlay(game *Game, _ IPlayer, _ ...uint32) protos.ErrorCode {
+	if game.PendingDraw > 0 {
+		return protos.ErrorCode_must_stack_or_draw
+	}
+	if game.WantColor == ColorBlack || game.WantColor == c.Color() || game.LastCard.Number() == c.Number() {
+		return protos.ErrorCode_success
 	}
-	return game.WantColor == c.Color() || game.LastCard.Number() == c.Number()
+	return protos.ErrorCode_card_not_match
 }
 
 func (c *cardReverse) Execute(game *Game, _ IPlayer, _ ...uint32) {
@@ -139,6 +172,10 @@ func (c *cardReverse) Execute(game *Game, _ IPlayer, _ ...uint32) {
 	game.NextPlayer(1)
 }
 
+func (c *cardReverse) Score() int {
+	return 20
+}
+
 func (c *cardReverse) String() string {
 	return c.Color().String() + "转向"
 }
@@ -155,20 +192,36 @@ func newPlus2Card(id, color uint32) ICard {
 	return &cardPlus2{colorfulCard{baseCard{id}, Color(color)}}
 }
 
-func (c *cardPlus2) CanPlay(game *Game, _ IPlayer, _ ...uint32) bool {
-	if game.WantColor == ColorBlack {
-		return true
+func (c *cardPlus2) CanPlay(game *Game, _ IPlayer, _ ...uint32) protos.ErrorCode {
+	if game.PendingDraw > 0 {
+		if game.Rules.StackPlus2 && game.LastCard.Number() == c.Number() {
+			return protos.ErrorCode_success
+		}
+		return protos.ErrorCode_must_stack_or_draw
+	}
+	if game.WantColor == ColorBlack || game.WantColor == c.Color() || game.LastCard.Number() == c.Number() {
+		return protos.ErrorCode_success
 	}
-	return game.WantColor == c.Color() || game.LastCard.Number() == c.Number()
+	return protos.ErrorCode_card_not_match
 }
 
 func (c *cardPlus2) Execute(game *Game, player IPlayer, _ ...uint32) {
+	if game.Rules.StackPlus2 || game.Rules.StackPlus4 {
+		game.LastCard = c
+		game.WantColor = c.Color()
+		game.addPendingDraw(2)
+		return
+	}
 	player.GetNextPlayer(1).Draw(2)
 	game.LastCard = c
 	game.WantColor = c.Color()
 	game.NextPlayer(2)
 }
 
+func (c *cardPlus2) Score() int {
+	return 20
+}
+
 func (c *cardPlus2) String() string {
 	return c.Color().String() + "+2"
 }
@@ -185,12 +238,15 @@ func newWildCard(id uint32) ICard {
 	return &cardWild{baseCard{id}}
 }
 
-func (c *cardWild) CanPlay(_ *Game, _ IPlayer, args ...uint32) bool {
-	if len(args) == 1 && Color(args[0]) >= ColorRed && Color(args[0]) <= ColorBlue {
-		return true
+func (c *cardWild) CanPlay(game *Game, _ IPlayer, args ...uint32) protos.ErrorCode {
+	if game.PendingDraw > 0 {
+		return protos.ErrorCode_must_stack_or_draw
+	}
+	if len(args) > 0 && Color(args[0]) >= ColorRed && Color(args[0]) <= ColorBlue {
+		return protos.ErrorCode_success
 	}
 	logger.Error("参数错误")
-	return false
+	return protos.ErrorCode_invalid_want_color
 }
 
 func (c *cardWild) Execute(game *Game, player IPlayer, args ...uint32) {
@@ -201,6 +257,10 @@ func (c *cardWild) Execute(game *Game, player IPlayer, args ...uint32) {
 	game.NextPlayer(1)
 }
 
+func (c *cardWild) Score() int {
+	return 50
+}
+
 func (c *cardWild) String() string {
 	return "黑色变色"
 }
@@ -221,29 +281,67 @@ func newPlus4Card(id uint32) ICard {
 	return &cardPlus4{baseCard{id}}
 }
 
-func (c *cardPlus4) CanPlay(game *Game, player IPlayer, args ...uint32) bool {
-	if len(args) != 1 || Color(args[0]) < ColorRed || Color(args[0]) > ColorBlue {
+func (c *cardPlus4) CanPlay(game *Game, player IPlayer, args ...uint32) protos.ErrorCode {
+	if len(args) == 0 || Color(args[0]) < ColorRed || Color(args[0]) > ColorBlue {
 		logger.Error("参数错误")
-		return false
+		return protos.ErrorCode_invalid_want_color
 	}
-	canPlay := true
+	if game.PendingDraw > 0 {
+		if game.Rules.StackPlus4 {
+			return protos.ErrorCode_success
+		}
+		return protos.ErrorCode_must_stack_or_draw
+	}
+	if game.Rules.ChallengePlus4 {
+		return protos.ErrorCode_success // 官方规则下任何时候都可以打出，由下家决定是否质疑
+	}
+	result := protos.ErrorCode_success
 	player.ForeachCards(func(card ICard) bool {
-		if _, ok := card.(*cardPlus4); !ok && card.CanPlay(game, player, args...) {
-			canPlay = false
+		if _, ok := card.(*cardPlus4); !ok && card.CanPlay(game, player, player.base().autoArgs(card)...) == protos.ErrorCode_success {
+			result = protos.ErrorCode_plus4_not_allowed
 			return false
 		}
 		return true
 	})
-	return canPlay
+	return result
 }
 
-func (c *cardPlus4) Execute(game *Game, player IPlayer, _ ...uint32) {
+func (c *cardPlus4) Execute(game *Game, player IPlayer, args ...uint32) {
+	if game.Rules.ChallengePlus4 && game.PendingDraw == 0 && len(args) > 0 {
+		// 打出+4时手里有和当时要出的颜色相同的牌，就是不合规的
+		illegal := false
+		player.ForeachCards(func(card ICard) bool {
+			illegal = card.Color() != ColorBlack && card.Color() == game.WantColor
+			return !illegal
+		})
+		game.LastCard = c
+		game.WantColor = Color(args[0])
+		game.NextPlayer(1)
+		game.plus4Challenge = &plus4Challenge{player: player.Location(), illegal: illegal} // 轮到下家之后才开始等他决定是否质疑
+		return
+	}
+	if game.Rules.StackPlus4 {
+		game.LastCard = c
+		game.WantColor = c.Color()
+		if len(args) > 0 {
+			game.WantColor = Color(args[0])
+		}
+		game.addPendingDraw(4)
+		return
+	}
 	player.GetNextPlayer(1).Draw(4)
 	game.LastCard = c
 	game.WantColor = c.Color()
+	if len(args) > 0 {
+		game.WantColor = Color(args[0])
+	}
 	game.NextPlayer(2)
 }
 
+func (c *cardPlus4) Score() int {
+	return 50
+}
+
 func (c *cardPlus4) String() string {
 	return "黑色+4"
 }
@@ -262,31 +360,9 @@ type Deck struct {
 	random      *rand.Rand
 }
 
-func NewDeck() *Deck {
-	d := new(Deck)
-	d.random = rand.New(rand.NewSource(time.Now().Unix()))
-	id := uint32(1)
-	for i := uint32(1); i < 4; i++ {
-		d.cards = append(d.cards, newNumberCard(id, i, 0))
-		id++
-		for j := uint32(1); j < 10; j++ {
-			d.cards = append(d.cards, newNumberCard(id, i, j))
-			id++
-			d.cards = append(d.cards, newNumberCard(id, i, j))
-			id++
-		}
-		d.cards = append(d.cards, newSkipCard(id, i))
-		id++
-		d.cards = append(d.cards, newReverseCard(id, i))
-		id++
-		d.cards = append(d.cards, newPlus2Card(id, i))
-	}
-	for i := 1; i < 4; i++ {
-		d.cards = append(d.cards, newWildCard(id))
-		id++
-		d.cards = append(d.cards, newPlus4Card(id))
-		id++
-	}
+// NewDeck 按 LoadDeckSpec 读取的配置创建一副洗好的牌，之后洗牌都使用random
+func NewDeck(random *rand.Rand) *Deck {
+	d := &Deck{cards: deckSpec.Cards(), random: random}
 	d.Shuffle()
 	return d
 }
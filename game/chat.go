@@ -0,0 +1,130 @@
+package game
+
+import (
+	"fmt"
+	"github.com/CuteReimu/uno-server/config"
+	"github.com/CuteReimu/uno-server/protos"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// WordFilter 聊天消息的敏感词过滤器
+type WordFilter interface {
+	// Filter 返回把敏感词替换掉之后的消息
+	Filter(text string) string
+}
+
+var wordFilter WordFilter
+
+// SetWordFilter 替换默认的敏感词过滤器，要在服务器启动之前调用
+func SetWordFilter(filter WordFilter) {
+	wordFilter = filter
+}
+
+// getWordFilter 没有调用过 SetWordFilter 的话，使用按配置chat.banned_words逐个替换的过滤器
+func getWordFilter() WordFilter {
+	if wordFilter == nil {
+		wordFilter = newBannedWordFilter(config.GlobalConfig.GetStringSlice("chat.banned_words"))
+	}
+	return wordFilter
+}
+
+// bannedWordFilter 把敏感词中的每个字替换成*，不区分大小写
+type bannedWordFilter struct {
+	words []string
+}
+
+func newBannedWordFilter(words []string) *bannedWordFilter {
+	f := new(bannedWordFilter)
+	for _, word := range words {
+		if word != "" {
+			f.words = append(f.words, strings.ToLower(word))
+		}
+	}
+	return f
+}
+
+func (f *bannedWordFilter) Filter(text string) string {
+	for _, word := range f.words {
+		lower := strings.ToLower(text)
+		// 大小写转换后长度不变时才能按下标替换，否则只替换完全相同的写法
+		if len(lower) != len(text) {
+			text = strings.ReplaceAll(text, word, strings.Repeat("*", utf8.RuneCountInString(word)))
+			continue
+		}
+		var sb strings.Builder
+		for {
+			index := strings.Index(lower, word)
+			if index < 0 {
+				break
+			}
+			sb.WriteString(text[:index])
+			sb.WriteString(strings.Repeat("*", utf8.RuneCountInString(word)))
+			text, lower = text[index+len(word):], lower[index+len(word):]
+		}
+		sb.WriteString(text)
+		text = sb.String()
+	}
+	return text
+}
+
+// rateLimiter 令牌桶，每秒恢复chat.rate个令牌，最多攒chat.burst个
+type rateLimiter struct {
+	tokens float64
+	last   time.Time
+}
+
+func (l *rateLimiter) allow(now time.Time) bool {
+	rate := config.GlobalConfig.GetFloat64("chat.rate")
+	burst := float64(config.GlobalConfig.GetInt("chat.burst"))
+	if l.last.IsZero() {
+		l.tokens = burst
+	} else {
+		l.tokens = min(burst, l.tokens+now.Sub(l.last).Seconds()*rate)
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// chat 玩家发聊天消息
+func (game *Game) chat(player *HumanPlayer, text string) protos.ErrorCode {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return protos.ErrorCode_chat_empty
+	}
+	if utf8.RuneCountInString(text) > config.GlobalConfig.GetInt("chat.max_length") {
+		return protos.ErrorCode_chat_too_long
+	}
+	if !player.chatLimiter.allow(game.Clock.Now()) {
+		return protos.ErrorCode_chat_too_fast
+	}
+	text = getWordFilter().Filter(text)
+	location := player.Location()
+	logger.Info(fmt.Sprintf("%d号玩家（%s）说：%s", location, player.identity.Name, text))
+	game.recordEvent(Event{Type: EventChat, Player: location, Text: text})
+	for _, p := range game.audience() {
+		p.NotifyChat(location, text, protos.Emote_emote_none)
+	}
+	return protos.ErrorCode_success
+}
+
+// emote 玩家发快捷表情
+func (game *Game) emote(player *HumanPlayer, emote protos.Emote) protos.ErrorCode {
+	if _, ok := protos.Emote_name[int32(emote)]; !ok || emote == protos.Emote_emote_none {
+		return protos.ErrorCode_invalid_emote
+	}
+	if !player.chatLimiter.allow(game.Clock.Now()) {
+		return protos.ErrorCode_chat_too_fast
+	}
+	location := player.Location()
+	game.recordEvent(Event{Type: EventEmote, Player: location, Text: emote.String()})
+	for _, p := range game.audience() {
+		p.NotifyChat(location, "", emote)
+	}
+	return protos.ErrorCode_success
+}
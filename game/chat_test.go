@@ -0,0 +1,87 @@
+package game
+
+import (
+	"github.com/CuteReimu/uno-server/config"
+	"github.com/CuteReimu/uno-server/protos"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBannedWordFilter(t *testing.T) {
+	f := newBannedWordFilter([]string{"Bad", "坏蛋", ""})
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"good game", "good game"},
+		{"bad game", "*** game"},
+		{"BaD game, bAd luck", "*** game, *** luck"},
+		{"你这个坏蛋！", "你这个**！"},
+		{"坏蛋bad坏蛋", "*******"},
+		{"ＢＡＤ", "ＢＡＤ"},   // 全角字母不是敏感词
+		{"İbad", "İ***"}, // 大小写转换后长度会变的文本，只替换完全相同的写法
+	}
+	for _, tt := range tests {
+		if got := f.Filter(tt.text); got != tt.want {
+			t.Errorf("Filter(%q) = %q，应该是%q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	setConfig(t, "chat.rate", 0.5)
+	setConfig(t, "chat.burst", 2)
+	c := &testClock{now: time.Unix(0, 0)}
+	var l rateLimiter
+	steps := []struct {
+		after time.Duration
+		want  bool
+	}{
+		{0, true}, // 一开始攒满了2个令牌
+		{0, true},
+		{0, false},
+		{time.Second, false}, // 1秒只恢复半个令牌
+		{time.Second, true},
+		{10 * time.Second, true}, // 最多攒2个令牌
+		{0, true},
+		{0, false},
+	}
+	for i, step := range steps {
+		c.now = c.now.Add(step.after)
+		if got := l.allow(c.Now()); got != step.want {
+			t.Fatalf("第%d次发言：allow() = %v，应该是%v", i+1, got, step.want)
+		}
+	}
+}
+
+func TestChat(t *testing.T) {
+	setConfig(t, "chat.rate", 0.5)
+	setConfig(t, "chat.burst", 10)
+	SetWordFilter(newBannedWordFilter([]string{"bad"}))
+	defer SetWordFilter(nil)
+	g, _, _, sessions := newTestGame(t, 0, 2, Rules{})
+	tests := []struct {
+		text string
+		want protos.ErrorCode
+	}{
+		{"", protos.ErrorCode_chat_empty},
+		{" \t\n", protos.ErrorCode_chat_empty},
+		{strings.Repeat("长", config.GlobalConfig.GetInt("chat.max_length")+1), protos.ErrorCode_chat_too_long},
+		{"  Bad luck  ", protos.ErrorCode_success},
+	}
+	for _, tt := range tests {
+		if code := g.chat(g.humanMap[sessions[0].ID()], tt.text); code != tt.want {
+			t.Errorf("chat(%q) = %s，应该是%s", tt.text, code, tt.want)
+		}
+	}
+	var got []string
+	for _, msg := range sessions[1].sent {
+		if chat, ok := msg.(*protos.ChatToc); ok {
+			got = append(got, chat.Text)
+		}
+	}
+	if len(got) != 1 || got[0] != "*** luck" {
+		t.Errorf("其他玩家应该只收到过滤后的一条消息，实际收到%q", got)
+	}
+}
@@ -0,0 +1,26 @@
+package game
+
+import "time"
+
+// Clock 游戏中用到的时钟。服务器使用真实的时钟，模拟对局时换成虚拟时钟，就不用真的等待了
+type Clock interface {
+	Now() time.Time
+	// AfterFunc 经过d之后调用f，f中如果要访问游戏，需要投递到游戏的事件队列中
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer 由 Clock.AfterFunc 创建的定时器
+type Timer interface {
+	// Stop 停止定时器，如果定时器已经触发或者已经停止了，返回false
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
@@ -0,0 +1,169 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"github.com/CuteReimu/uno-server/config"
+)
+
+// allColors 打出黑牌时可以选择的颜色，每种颜色都要有牌
+var allColors = []Color{ColorRed, ColorGreen, ColorYellow, ColorBlue}
+
+// DeckSpec 一副牌的组成，每种颜色的牌都一样
+type DeckSpec struct {
+	Numbers [10]int // 每种颜色的0~9各几张
+	Skip    int     // 每种颜色的跳过各几张
+	Reverse int     // 每种颜色的反转各几张
+	Plus2   int     // 每种颜色的+2各几张
+	Wild    int     // 变色牌几张
+	Plus4   int     // +4几张
+}
+
+// StandardDeck 标准的108张UNO牌：每种颜色一张0，1~9、跳过、反转、+2各两张，变色牌和+4各四张
+var StandardDeck = DeckSpec{
+	Numbers: [10]int{1, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+	Skip:    2,
+	Reverse: 2,
+	Plus2:   2,
+	Wild:    4,
+	Plus4:   4,
+}
+
+var deckSpec = StandardDeck
+
+// LoadDeckSpec 读取配置deck，检查没有问题并且够坐满的房间发牌后，之后的每一局都用它创建牌堆。没有配置则使用 StandardDeck
+func LoadDeckSpec() error {
+	spec := StandardDeck
+	if config.GlobalConfig.IsSet("deck") {
+		numbers := config.GlobalConfig.GetIntSlice("deck.numbers")
+		if len(numbers) != len(spec.Numbers) {
+			return fmt.Errorf("deck.numbers要配置0~9每个数字的张数，现在配置了%d个", len(numbers))
+		}
+		copy(spec.Numbers[:], numbers)
+		spec.Skip = config.GlobalConfig.GetInt("deck.skip")
+		spec.Reverse = config.GlobalConfig.GetInt("deck.reverse")
+		spec.Plus2 = config.GlobalConfig.GetInt("deck.plus2")
+		spec.Wild = config.GlobalConfig.GetInt("deck.wild")
+		spec.Plus4 = config.GlobalConfig.GetInt("deck.plus4")
+	}
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+	if need := MaxPlayerCount*7 + 1; spec.Total() < need {
+		return fmt.Errorf("一副牌只有%d张，%d个人每人发7张再翻出一张至少需要%d张", spec.Total(), MaxPlayerCount, need)
+	}
+	deckSpec = spec
+	logger.Info(fmt.Sprintf("一副牌共%d张", spec.Total()))
+	return nil
+}
+
+// Total 一副牌的总张数
+func (s DeckSpec) Total() int {
+	total := 0
+	for _, count := range s.Counts() {
+		total += count
+	}
+	return total
+}
+
+// Counts 每种颜色的每种牌的张数，key是牌的 Number
+func (s DeckSpec) Counts() map[uint32]int {
+	counts := make(map[uint32]int)
+	for _, color := range allColors {
+		for num, count := range s.Numbers {
+			counts[cardKey(color, uint32(num))] += count
+		}
+		counts[cardKey(color, 10)] += s.Skip
+		counts[cardKey(color, 11)] += s.Reverse
+		counts[cardKey(color, 12)] += s.Plus2
+	}
+	counts[cardKey(ColorBlack, 13)] += s.Wild
+	counts[cardKey(ColorBlack, 14)] += s.Plus4
+	return counts
+}
+
+// cardKey 按颜色和 Number 区分每种牌
+func cardKey(color Color, num uint32) uint32 {
+	return uint32(color)<<8 | num
+}
+
+// Cards 按顺序创建一副牌，ID从1开始连续编号，因为0表示摸牌
+func (s DeckSpec) Cards() []ICard {
+	var cards []ICard
+	id := uint32(1)
+	add := func(count int, newCard func(id uint32) ICard) {
+		for range count {
+			cards = append(cards, newCard(id))
+			id++
+		}
+	}
+	for _, color := range allColors {
+		for num, count := range s.Numbers {
+			add(count, func(id uint32) ICard { return newNumberCard(id, uint32(color), uint32(num)) })
+		}
+		add(s.Skip, func(id uint32) ICard { return newSkipCard(id, uint32(color)) })
+		add(s.Reverse, func(id uint32) ICard { return newReverseCard(id, uint32(color)) })
+		add(s.Plus2, func(id uint32) ICard { return newPlus2Card(id, uint32(color)) })
+	}
+	add(s.Wild, newWildCard)
+	add(s.Plus4, newPlus4Card)
+	return cards
+}
+
+// Validate 检查张数都不是负数，每种颜色都有牌，并且创建出来的牌没有重复的ID
+func (s DeckSpec) Validate() error {
+	for _, count := range append(s.Numbers[:], s.Skip, s.Reverse, s.Plus2, s.Wild, s.Plus4) {
+		if count < 0 {
+			return errors.New("牌的张数不能是负数")
+		}
+	}
+	return s.check(s.Cards())
+}
+
+// check 检查cards是不是正好是一副牌：没有重复的ID，每种颜色都有牌，每种牌的张数都和配置的一致
+func (s DeckSpec) check(cards []ICard) error {
+	ids := make(map[uint32]bool, len(cards))
+	counts := make(map[uint32]int)
+	for _, card := range cards {
+		if card.Id() == 0 || ids[card.Id()] {
+			return fmt.Errorf("牌的ID重复或者为0：%d（%s）", card.Id(), card)
+		}
+		ids[card.Id()] = true
+		counts[cardKey(card.Color(), card.Number())]++
+	}
+	for _, color := range allColors {
+		if !hasColor(counts, color) {
+			return fmt.Errorf("缺少%s的牌", color)
+		}
+	}
+	for key, count := range s.Counts() {
+		if counts[key] != count {
+			return fmt.Errorf("%s的%d号牌有%d张，应该有%d张", Color(key>>8), key&0xff, counts[key], count)
+		}
+	}
+	if len(cards) != s.Total() {
+		return fmt.Errorf("一共有%d张牌，应该有%d张", len(cards), s.Total())
+	}
+	return nil
+}
+
+func hasColor(counts map[uint32]int, color Color) bool {
+	for key, count := range counts {
+		if Color(key>>8) == color && count > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCards 检查牌堆、弃牌堆和所有人的手牌加起来正好是一副牌
+func (game *Game) checkCards() error {
+	cards := append(append([]ICard(nil), game.Deck.cards...), game.Deck.discardPile...)
+	for _, player := range game.Players {
+		player.ForeachCards(func(card ICard) bool {
+			cards = append(cards, card)
+			return true
+		})
+	}
+	return deckSpec.check(cards)
+}
@@ -0,0 +1,156 @@
+package game
+
+import (
+	"github.com/CuteReimu/uno-server/config"
+	"testing"
+	"time"
+)
+
+func TestStandardDeck(t *testing.T) {
+	if err := StandardDeck.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	cards := StandardDeck.Cards()
+	if len(cards) != 108 || StandardDeck.Total() != 108 {
+		t.Fatalf("标准的一副牌应该有108张，实际有%d张", len(cards))
+	}
+	colors := make(map[Color]int)
+	for _, card := range cards {
+		colors[card.Color()]++
+	}
+	for _, color := range allColors {
+		if colors[color] != 25 {
+			t.Errorf("%s的牌应该有25张，实际有%d张", color, colors[color])
+		}
+	}
+	if colors[ColorBlack] != 8 {
+		t.Errorf("黑牌应该有8张，实际有%d张", colors[ColorBlack])
+	}
+}
+
+func TestDeckSpecValidate(t *testing.T) {
+	negative := StandardDeck
+	negative.Plus2 = -1
+	if negative.Validate() == nil {
+		t.Error("张数是负数时应该报错")
+	}
+	noColor := DeckSpec{Wild: 4, Plus4: 4}
+	if noColor.Validate() == nil {
+		t.Error("没有颜色牌时应该报错")
+	}
+	cards := StandardDeck.Cards()
+	cards[1] = newNumberCard(cards[0].Id(), uint32(cards[1].Color()), cards[1].Number())
+	if StandardDeck.check(cards) == nil {
+		t.Error("ID重复时应该报错")
+	}
+	if StandardDeck.check(StandardDeck.Cards()[1:]) == nil {
+		t.Error("少了牌时应该报错")
+	}
+}
+
+// TestCardConservation 机器人对局的每一步之后，牌堆、弃牌堆和所有人的手牌加起来都正好是一副牌
+func TestCardConservation(t *testing.T) {
+	rules := []Rules{
+		{},
+		{SevenZero: true, JumpIn: true},
+		{StackPlus2: true, StackPlus4: true, ChallengePlus4: true},
+		{DrawUntilPlayable: true, ForcePlay: true},
+	}
+	for _, r := range rules {
+		for count := 2; count <= 6; count++ {
+			for seed := int64(1); seed <= 5; seed++ {
+				playCheckingCards(t, count, r, seed)
+			}
+		}
+	}
+}
+
+func playCheckingCards(t *testing.T, count int, rules Rules, seed int64) {
+	var robots []Strategy
+	for i := range count {
+		strategy, err := NewStrategy([]string{"easy", "normal", "hard"}[i%3])
+		if err != nil {
+			t.Fatal(err)
+		}
+		robots = append(robots, strategy)
+	}
+	q := new(testQueue)
+	c := &testClock{now: time.Unix(0, 0)}
+	g := NewGame(q, count, robots, rules)
+	g.Clock = c
+	g.InitialSeed = seed
+	g.Recording = false
+	q.check = func() {
+		if g.Deck == nil {
+			return
+		}
+		if err := g.checkCards(); err != nil {
+			t.Fatalf("%d人，房规%+v，种子%d：%v", count, rules, seed, err)
+		}
+	}
+	g.Start()
+	q.drain()
+	for steps := 0; g.IsPlaying() && c.advance(); steps++ {
+		q.drain()
+		if steps > 10000 {
+			t.Fatalf("%d人，房规%+v，种子%d：对局没有结束", count, rules, seed)
+		}
+	}
+}
+
+func TestLoadDeckSpec(t *testing.T) {
+	t.Cleanup(func() {
+		config.GlobalConfig.Set("deck.numbers", StandardDeck.Numbers[:])
+		config.GlobalConfig.Set("deck.skip", StandardDeck.Skip)
+		config.GlobalConfig.Set("deck.wild", StandardDeck.Wild)
+		deckSpec = StandardDeck
+	})
+	tests := []struct {
+		name    string
+		numbers []int
+		skip    int
+		wild    int
+		ok      bool
+	}{
+		{"标准的一副牌", StandardDeck.Numbers[:], 2, 4, true},
+		{"数字的个数不对", []int{1, 2, 3}, 2, 4, false},
+		{"张数是负数", StandardDeck.Numbers[:], -1, 4, false},
+		{"刚好够10个人", []int{1, 2, 2, 2, 2, 2, 1, 0, 0, 0}, 0, 3, true},    // 4*(12+2+2)+3+4 = 71
+		{"差一张不够10个人", []int{1, 2, 2, 2, 2, 2, 1, 0, 0, 0}, 0, 2, false}, // 70
+		{"只有数字牌", []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.GlobalConfig.Set("deck.numbers", tt.numbers)
+			config.GlobalConfig.Set("deck.skip", tt.skip)
+			config.GlobalConfig.Set("deck.wild", tt.wild)
+			if err := LoadDeckSpec(); (err == nil) != tt.ok {
+				t.Errorf("LoadDeckSpec() err = %v", err)
+			}
+		})
+	}
+}
+
+// TestStartWithTooFewCards 没有经过 LoadDeckSpec 检查的牌不够发牌时，不能因为翻不出第一张牌而崩溃
+func TestStartWithTooFewCards(t *testing.T) {
+	deckSpec = DeckSpec{Numbers: [10]int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}}
+	t.Cleanup(func() { deckSpec = StandardDeck })
+	var robots []Strategy
+	for range MaxPlayerCount {
+		strategy, err := NewStrategy("easy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		robots = append(robots, strategy)
+	}
+	q := &testQueue{check: func() {}}
+	g := NewGame(q, MaxPlayerCount, robots, Rules{})
+	g.Clock = &testClock{now: time.Unix(0, 0)}
+	g.InitialSeed = 1
+	g.Recording = false
+	g.Start()
+	q.drain()
+	if g.Phase() != PhaseWaiting {
+		t.Errorf("翻不出第一张牌时应该回到等待开局，现在是%s", g.Phase())
+	}
+}
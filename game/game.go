@@ -7,12 +7,8 @@ import (
 	"github.com/CuteReimu/uno-server/protos"
 	"github.com/CuteReimu/uno-server/utils"
 	"github.com/davyxu/cellnet"
-	"github.com/davyxu/cellnet/msglog"
-	"github.com/davyxu/cellnet/peer"
-	_ "github.com/davyxu/cellnet/peer/tcp"
-	"github.com/davyxu/cellnet/proc"
-	_ "github.com/davyxu/cellnet/proc/tcp"
-	"os"
+	"math/rand"
+	"slices"
 	"time"
 )
 
@@ -22,14 +18,67 @@ type Game struct {
 	Dir              bool
 	Players          []IPlayer
 	TotalPlayerCount int
+	RobotCount       int
+	Rules            Rules
+	Match            Match
 	Deck             *Deck
+	Clock            Clock
+	InitialSeed      int64 // 第一局的随机数种子，为0则随机生成。之后每一局的种子由上一局的随机数生成器产生
+	Seed             int64 // 本局的随机数种子
+	Recording        bool  // 是否把每一局记录到文件中
 	LastCard         ICard
 	WantColor        Color
 	WhoseTurn        int
+	PendingDraw      int // 叠加+2/+4时累积的罚摸牌数
 	cellnet.EventQueue
+	random         *rand.Rand // 本局唯一的随机数来源，牌堆和每个座位的随机数生成器都由它产生
+	record         *Record    // 本局的记录，不记录的时候为nil
+	humanMap       map[int64]*HumanPlayer
+	spectators     []*Spectator
+	phase          Phase           // 房间所处的阶段
+	generation     int             // 开过的局数，每次发牌时加一，用来判断延迟执行的回调是否已经过期
+	unoVulnerable  int             // 只剩一张牌却忘记喊UNO的玩家的座位号，在他的下家行动之前都可以被抓，没有则为-1
+	plus4Challenge *plus4Challenge // 官方规则下打出+4之后，等待下家决定是否质疑
+	drawnCard      ICard           // 摸到了能打出的牌，等待摸牌的玩家决定是否打出
+	turnTimer      Timer           // 当前回合的计时器，不限时的时候为nil
+	turnSeq        int             // 每次轮到某人时加一，用来判断计时器是否已经过期
+	turnStart      time.Time       // 当前回合开始的时间
+	turnDeadline   time.Time       // 当前回合的截止时间，不限时的时候为零值
+}
+
+type plus4Challenge struct {
+	player  int  // 打出+4的玩家
+	illegal bool // 打出+4时手里是否有和当时要出的颜色相同的牌
+}
+
+// MaxPlayerCount 一个房间最多的座位数
+const MaxPlayerCount = 10
+
+// NewGame 创建一局游戏，robots中每个策略对应一个机器人，机器人先入座，玩家通过 Join 加入。所有事件都投递到queue中处理
+func NewGame(queue cellnet.EventQueue, totalCount int, robots []Strategy, rules Rules) *Game {
+	game := &Game{
+		TotalPlayerCount: totalCount,
+		RobotCount:       len(robots),
+		Rules:            rules,
+		Match:            Match{TargetScore: rules.TargetScore},
+		Clock:            realClock{},
+		InitialSeed:      config.GlobalConfig.GetInt64("seed"),
+		Recording:        config.GlobalConfig.GetBool("replay.enabled"),
+		EventQueue:       queue,
+		humanMap:         make(map[int64]*HumanPlayer),
+		unoVulnerable:    -1,
+	}
+	for _, strategy := range robots {
+		game.Players = append(game.Players, &RobotPlayer{basePlayer: basePlayer{location: len(game.Players)}, strategy: strategy})
+	}
+	return game
 }
 
 func (game *Game) NextPlayer(location int) {
+	game.stopTurnTimer()
+	// 换人之后，上一个回合中等待决定的+4质疑和刚摸到的牌都作废了
+	game.plus4Challenge = nil
+	game.drawnCard = nil
 	if game.Dir {
 		location = -location
 	}
@@ -38,86 +87,393 @@ func (game *Game) NextPlayer(location int) {
 		game.WhoseTurn += game.TotalPlayerCount
 	}
 	game.WhoseTurn %= game.TotalPlayerCount
+	game.recordEvent(Event{Type: EventTurn, Player: game.WhoseTurn, Dir: game.Dir})
+	game.startTurnTimer()
+	for _, player := range game.audience() {
+		player.NotifyTurn(game.WhoseTurn, game.Dir)
+	}
+}
+
+// closeUnoWindow 有其他玩家行动了，就不能再抓之前忘记喊UNO的玩家了
+func (game *Game) closeUnoWindow(actor *basePlayer) {
+	if game.unoVulnerable >= 0 && game.unoVulnerable != actor.location {
+		game.unoVulnerable = -1
+	}
+}
+
+// unoVulnerablePlayer 可以被抓的忘记喊UNO的玩家，没有则为nil。
+// 座位可能被机器人接管或者被玩家接替，所以只记座位号，用的时候再找坐在那里的玩家
+func (game *Game) unoVulnerablePlayer() *basePlayer {
+	if game.unoVulnerable < 0 || game.unoVulnerable >= len(game.Players) {
+		return nil
+	}
+	return game.Players[game.unoVulnerable].base()
+}
+
+// addPendingDraw 累积罚摸牌，轮到下家选择继续叠加还是摸牌
+func (game *Game) addPendingDraw(count int) {
+	game.PendingDraw += count
+	logger.Info(fmt.Sprintf("累积罚摸%d张牌", game.PendingDraw))
+	game.NextPlayer(1)
+	game.notifyPendingDraw()
+}
+
+func (game *Game) notifyPendingDraw() {
+	for _, player := range game.audience() {
+		player.NotifyPendingDraw(game.WhoseTurn, game.PendingDraw)
+	}
+}
+
+// swapCards 两个玩家交换手牌
+func (game *Game) swapCards(a, b IPlayer) {
+	logger.Info(fmt.Sprintf("%d号玩家和%d号玩家交换手牌", a.Location(), b.Location()))
+	game.recordEvent(Event{Type: EventSwap, Player: a.Location(), Target: b.Location()})
+	a.base().cards, b.base().cards = b.base().cards, a.base().cards
+	for _, player := range game.audience() {
+		player.NotifyGameState()
+	}
+}
+
+// passCards 所有玩家把手牌传给下一个玩家
+func (game *Game) passCards() {
+	logger.Info("所有玩家把手牌传给下一个玩家")
+	game.recordEvent(Event{Type: EventRotate})
+	cards := make([]map[uint32]ICard, len(game.Players))
+	for _, player := range game.Players {
+		cards[player.GetNextPlayer(1).Location()] = player.base().cards
+	}
+	for location, player := range game.Players {
+		player.base().cards = cards[location]
+	}
+	for _, player := range game.audience() {
+		player.NotifyGameState()
+	}
+}
+
+// RobotStrategies 每个机器人座位使用的策略（不包括替玩家托管的机器人）
+func (game *Game) RobotStrategies() []string {
+	var names []string
+	for _, player := range game.Players {
+		if robot, ok := player.(*RobotPlayer); ok && !robot.substitute {
+			names = append(names, robot.strategy.Name())
+		}
+	}
+	return names
+}
+
+// IsFull 座位是否已经坐满
+func (game *Game) IsFull() bool {
+	return len(game.Players) >= game.TotalPlayerCount
+}
+
+// IsPlaying 是否正在一局游戏中
+func (game *Game) IsPlaying() bool {
+	return game.phase == PhasePlaying
+}
+
+// HumanCount 房间中占着座位的玩家人数（不包括机器人，包括断线等待重连的玩家）
+func (game *Game) HumanCount() int {
+	count := 0
 	for _, player := range game.Players {
+		if _, ok := player.(*HumanPlayer); ok {
+			count++
+		}
+	}
+	return count
+}
+
+// Join 玩家入座，坐满并且所有人都准备好后自动开始游戏。如果正在游戏中，可以接替中途离开的玩家的座位。
+// 一局结束后有人离开又有新玩家入座的话，要回到等待开局，所有人准备好后再开始下一局
+func (game *Game) Join(session cellnet.Session, identity Identity) bool {
+	if game.IsFull() {
+		return game.takeOver(session, identity)
+	}
+	player := &HumanPlayer{basePlayer: basePlayer{game: game, location: len(game.Players)}, Session: session, identity: identity, token: newReconnectToken()}
+	game.Players = append(game.Players, player)
+	game.humanMap[session.ID()] = player
+	logger.Info(fmt.Sprintf("玩家加入，还差%d人", game.TotalPlayerCount-len(game.Players)), "sessionId", session.ID())
+	if game.phase == PhaseRoundOver {
+		// 新来的玩家还没有准备，不能等下一局自动开始时直接发牌给他，所有人回到等待开局重新准备
+		game.wait()
+		return true
+	}
+	game.notifySeats()
+	game.notifyReadyState()
+	return true
+}
+
+// takeOver 接替由机器人托管的座位，并把当前局面发给他
+func (game *Game) takeOver(session cellnet.Session, identity Identity) bool {
+	if game.phase != PhasePlaying {
+		return false
+	}
+	for location, p := range game.Players {
+		if robot, ok := p.(*RobotPlayer); ok && robot.substitute {
+			player := &HumanPlayer{basePlayer: robot.basePlayer, Session: session, identity: identity, token: newReconnectToken()}
+			game.Players[location] = player
+			game.humanMap[session.ID()] = player
+			logger.Info(fmt.Sprintf("玩家接替了%d号座位", location), "sessionId", session.ID())
+			player.resync()
+			game.notifySeats()
+			return true
+		}
+	}
+	return false
+}
+
+// Leave 玩家离开。如果正在游戏中，由机器人接管他的座位直到本局结束
+func (game *Game) Leave(session cellnet.Session) {
+	player, ok := game.humanMap[session.ID()]
+	if !ok {
+		game.stopSpectating(session)
+		return
+	}
+	delete(game.humanMap, session.ID())
+	game.leave(player)
+}
+
+func (game *Game) leave(player *HumanPlayer) {
+	if game.phase != PhasePlaying {
+		game.Players = slices.DeleteFunc(game.Players, func(p IPlayer) bool { return p == player })
+		for location, p := range game.Players {
+			p.base().location = location
+		}
+		game.notifySeats()
+		game.notifyReadyState()
+		return
+	}
+	logger.Info(fmt.Sprintf("%d号玩家离开，由机器人接管", player.location))
+	robot := &RobotPlayer{basePlayer: player.basePlayer, strategy: defaultStrategy(), substitute: true}
+	game.Players[robot.location] = robot
+	game.notifySeats()
+	if game.WhoseTurn == robot.location {
+		robot.NotifyTurn(game.WhoseTurn, game.Dir)
+	}
+}
+
+// Disconnect 玩家断线。如果正在游戏中，保留他的座位等待重连，并返回重连凭证和这是他第几次断线，否则直接离开
+func (game *Game) Disconnect(session cellnet.Session) (token string, seq int, ok bool) {
+	player, ok := game.humanMap[session.ID()]
+	if !ok {
+		game.stopSpectating(session)
+		return "", 0, false
+	}
+	delete(game.humanMap, session.ID())
+	if game.phase != PhasePlaying {
+		game.leave(player)
+		return "", 0, false
+	}
+	logger.Info(fmt.Sprintf("%d号玩家断线，等待重连", player.location))
+	player.Session = nil
+	player.disconnects++
+	game.notifySeats()
+	if game.WhoseTurn == player.location {
 		player.NotifyTurn(game.WhoseTurn, game.Dir)
 	}
+	return player.token, player.disconnects, true
 }
 
-func (game *Game) Start(totalCount, robotCount int) {
-	humanCount := totalCount - robotCount
-	game.TotalPlayerCount = totalCount
-	index := 0
-	for ; index < robotCount; index++ {
-		game.Players = append(game.Players, new(RobotPlayer))
-	}
-	logger.Info(fmt.Sprintf("已加入%d个机器人，等待%d人加入。。。", robotCount, humanCount))
-
-	if !config.GlobalConfig.GetBool("log.tcp_debug_log") {
-		msglog.SetCurrMsgLogMode(msglog.MsgLogMode_Mute)
-	}
-	// 创建一个事件处理队列，整个服务器只有这一个队列处理事件，服务器属于单线程服务器
-	game.EventQueue = cellnet.NewEventQueue()
-
-	// 创建一个tcp的侦听器，名称为server，所有连接将事件投递到queue队列,单线程的处理
-	p := peer.NewGenericPeer("tcp.Acceptor", "server", config.GlobalConfig.GetString("listen_address"), game.EventQueue)
-
-	humanMap := make(map[int64]*HumanPlayer)
-	proc.BindProcessorHandler(p, "tcp.ltv", func(ev cellnet.Event) {
-		switch msg := ev.Message().(type) {
-		case *cellnet.SessionAccepted:
-			if index < totalCount {
-				player := &HumanPlayer{Session: ev.Session()}
-				game.Players = append(game.Players, player)
-				humanMap[player.Session.ID()] = player
-				index++
-				logger.Info("server accepted", "sessionId", ev.Session().ID())
-				if index == totalCount {
-					game.Post(game.start)
-				}
-			} else {
-				ev.Session().Close()
-				logger.Info("房间人数已满")
-			}
-		case *cellnet.SessionClosed:
-			logger.Info("session closed", "sessionId", ev.Session().ID())
-			if _, ok := humanMap[ev.Session().ID()]; ok {
-				logger.Info("目前不支持断线重连，程序将在3秒后关闭")
-				time.Sleep(time.Second * 3)
-				os.Exit(1)
-			}
-		case *protos.DiscardCardTos:
-			r := humanMap[ev.Session().ID()]
-			r.PlayCard(msg.CardId, msg.WantColor)
-		case *protos.RestartGameTos:
-			if index == totalCount {
-				game.Post(game.start)
+// Reconnect 玩家断线重连，回到原来的座位上。登录过的玩家只能回到自己的座位上
+func (game *Game) Reconnect(session cellnet.Session, identity Identity, token string) bool {
+	player := game.offlinePlayer(token)
+	if player == nil || player.identity.Id != "" && player.identity.Id != identity.Id {
+		return false
+	}
+	logger.Info(fmt.Sprintf("%d号玩家断线重连", player.location), "sessionId", session.ID())
+	player.Session = session
+	game.humanMap[session.ID()] = player
+	return true
+}
+
+// Resync 把当前局面重新发给重连的玩家，并告诉所有人他回来了
+func (game *Game) Resync(session cellnet.Session) {
+	if player, ok := game.humanMap[session.ID()]; ok {
+		player.resync()
+		game.notifySeats()
+	}
+}
+
+// notifySeats 座位有变化时，把所有座位的信息发给所有玩家和观战者
+func (game *Game) notifySeats() {
+	for _, player := range game.audience() {
+		player.NotifySeats()
+	}
+}
+
+// ReleaseSeat 断线的玩家超时仍未重连，则让出他的座位，返回是否让出了座位。
+// seq是 Disconnect 返回的断线次数，他重连之后又断线的话，之前那次断线的计时器就过期了
+func (game *Game) ReleaseSeat(token string, seq int) bool {
+	player := game.offlinePlayer(token)
+	if player == nil || player.disconnects != seq {
+		return false
+	}
+	logger.Info(fmt.Sprintf("%d号玩家超时未重连", player.location))
+	game.leave(player)
+	return true
+}
+
+func (game *Game) offlinePlayer(token string) *HumanPlayer {
+	for _, p := range game.Players {
+		if player, ok := p.(*HumanPlayer); ok && player.Session == nil && player.token == token {
+			return player
+		}
+	}
+	return nil
+}
+
+// Stop 结束游戏，房间解散时调用。所有还没执行的延迟回调都会过期
+func (game *Game) Stop() {
+	if game.phase == PhasePlaying {
+		game.abortRecord()
+	}
+	game.phase = PhaseWaiting
+	game.generation++
+	game.stopTurnTimer()
+}
+
+// Handle 处理房间内玩家发来的消息
+func (game *Game) Handle(session cellnet.Session, msg interface{}) {
+	player, ok := game.humanMap[session.ID()]
+	if !ok {
+		// 观战者只能请求完整的局面
+		if s := game.spectator(session); s != nil {
+			if _, ok := msg.(*protos.RequestStateTos); ok {
+				s.NotifyGameState()
 			}
 		}
-	})
-	p.Start()
-	game.StartLoop()
-	if humanCount == 0 {
-		game.Post(game.start)
+		return
+	}
+	switch msg := msg.(type) {
+	case *protos.DiscardCardTos:
+		target := (player.location + int(msg.TargetPlayerId)) % game.TotalPlayerCount
+		if code := player.PlayCard(msg.CardId, msg.WantColor, uint32(target)); code != protos.ErrorCode_success {
+			player.NotifyError(code, msg.CardId)
+		}
+	case *protos.CallUnoTos:
+		if code := player.CallUno(); code != protos.ErrorCode_success {
+			player.NotifyError(code, 0)
+		}
+	case *protos.CatchUnoTos:
+		location := (player.location + int(msg.PlayerId)) % game.TotalPlayerCount
+		if code := player.CatchUno(location); code != protos.ErrorCode_success {
+			player.NotifyError(code, 0)
+		}
+	case *protos.ChallengePlus4Tos:
+		if code := player.ChallengePlus4(msg.Challenge); code != protos.ErrorCode_success {
+			player.NotifyError(code, 0)
+		}
+	case *protos.PassTos:
+		if code := player.Pass(); code != protos.ErrorCode_success {
+			player.NotifyError(code, 0)
+		}
+	case *protos.RequestStateTos:
+		player.NotifyGameState()
+	case *protos.ChatTos:
+		if code := game.chat(player, msg.Text); code != protos.ErrorCode_success {
+			player.NotifyError(code, 0)
+		}
+	case *protos.EmoteTos:
+		if code := game.emote(player, msg.Emote); code != protos.ErrorCode_success {
+			player.NotifyError(code, 0)
+		}
+	case *protos.ReadyTos:
+		if code := game.setReady(player, msg.Ready); code != protos.ErrorCode_success {
+			player.NotifyError(code, 0)
+		}
+	case *protos.StartGameTos:
+		if code := game.startEarly(player); code != protos.ErrorCode_success {
+			player.NotifyError(code, 0)
+		}
+	case *protos.RestartGameTos:
+		if code := game.voteRestart(player, int(msg.Generation)); code != protos.ErrorCode_success {
+			player.NotifyError(code, 0)
+		}
 	}
-	game.Wait()
+}
+
+// Start 开始游戏，座位必须已经坐满。有玩家的游戏由玩家准备或者房主提前开局，只有机器人的游戏需要调用这个方法
+func (game *Game) Start() {
+	game.Post(game.start)
+}
+
+// nextSeed 新的一局的随机数种子。牌堆和机器人各自使用由它产生的随机数生成器，
+// 所以机器人做决定时用了多少随机数，不会影响之后摸到的牌，只要有种子和所有人的行动就能复现一局
+func (game *Game) nextSeed() int64 {
+	if game.random != nil {
+		return game.random.Int63()
+	}
+	if game.InitialSeed != 0 {
+		return game.InitialSeed
+	}
+	return time.Now().UnixNano()
 }
 
 func (game *Game) start() {
-	game.Deck = NewDeck()
+	if game.phase == PhaseDealing {
+		logger.Error("正在发牌，不能重新开始")
+		return
+	}
+	// 先停止计时，座位变少之后当前回合的座位号可能已经不存在了
+	game.stopTurnTimer()
+	// 上一局中途离开的玩家的座位空出来
+	game.Players = slices.DeleteFunc(game.Players, func(p IPlayer) bool {
+		r, ok := p.(*RobotPlayer)
+		return ok && r.substitute
+	})
+	// 上一局到此为止，它的记录作废，还没执行的回调也都过期了
+	game.abortRecord()
+	game.generation++
+	if !game.IsFull() {
+		game.wait()
+		logger.Info(fmt.Sprintf("还差%d人，等待玩家加入。。。", game.TotalPlayerCount-len(game.Players)))
+		return
+	}
+	for _, p := range game.Players {
+		if player, ok := p.(*HumanPlayer); ok {
+			player.restartVote = false
+		}
+	}
+	game.setPhase(PhaseDealing)
+	game.Match.newRound(game.Players)
+	game.Seed = game.nextSeed()
+	game.random = rand.New(rand.NewSource(game.Seed))
+	logger.Info(fmt.Sprintf("第%d局开始，随机数种子：%d", game.Match.Round, game.Seed))
+	game.Deck = NewDeck(rand.New(rand.NewSource(game.random.Int63())))
 	game.Dir = true
+	game.PendingDraw = 0
+	// 翻出的第一张牌是黑牌时不会选颜色，不能沿用上一局要出的颜色
+	game.WantColor = ColorBlack
+	game.LastCard = nil
+	game.unoVulnerable = -1
+	game.plus4Challenge = nil
+	game.drawnCard = nil
 	for location, player := range game.Players {
 		player.Init(game, location)
+		player.base().random = rand.New(rand.NewSource(game.random.Int63()))
 	}
+	for _, s := range game.spectators {
+		s.Send(s.initToc())
+	}
+	game.notifySeats()
 	for _, player := range game.Players {
 		player.Draw(7)
 	}
+	game.newRecord()
 	cards := game.Deck.Draw(1)
+	if len(cards) == 0 {
+		logger.Error("发完牌后牌堆里没有牌了，无法翻出第一张牌")
+		game.abortRecord()
+		game.wait()
+		return
+	}
 	logger.Info(fmt.Sprint("翻出了", cards[0]))
-	for _, player := range game.Players {
+	game.recordEvent(Event{Type: EventFlip, Cards: recordCards(cards[0])})
+	for _, player := range game.audience() {
 		player.NotifyDeckNum(len(game.Deck.cards))
 		player.NotifyDiscardCard(99999, cards[0], 0)
 	}
 	game.WhoseTurn = len(game.Players) - 1
 	game.Deck.Discard(cards...)
+	game.setPhase(PhasePlaying)
 	cards[0].Execute(game, game.Players[game.WhoseTurn])
 }
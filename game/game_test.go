@@ -0,0 +1,491 @@
+package game
+
+import (
+	"github.com/CuteReimu/uno-server/config"
+	"github.com/CuteReimu/uno-server/protos"
+	"github.com/CuteReimu/uno-server/utils"
+	"github.com/davyxu/cellnet"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	utils.SetLogLevel(slog.LevelError + 1)
+	config.GlobalConfig.SetConfigFile(filepath.Join("..", "config.yaml"))
+	if err := config.GlobalConfig.ReadInConfig(); err != nil {
+		panic(err)
+	}
+	// 游戏记录是在后台保存的，测试结束时可能还没写完，所以不能用t.TempDir()
+	dir, err := os.MkdirTemp("", "uno-replay")
+	if err != nil {
+		panic(err)
+	}
+	config.GlobalConfig.Set("replay.dir", dir)
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// setConfig 修改一项配置，测试结束后恢复原来的值
+func setConfig(t *testing.T, key string, value interface{}) {
+	old := config.GlobalConfig.Get(key)
+	config.GlobalConfig.Set(key, value)
+	t.Cleanup(func() { config.GlobalConfig.Set(key, old) })
+}
+
+// newTestGame 创建一个使用虚拟时钟和同步队列的房间，robots个easy机器人先入座，然后humans个玩家入座并准备好
+func newTestGame(t *testing.T, robots, humans int, rules Rules) (*Game, *testQueue, *testClock, []*testSession) {
+	g, q, c, sessions := newWaitingGame(t, robots+humans, robots, humans, rules)
+	for _, session := range sessions {
+		g.Handle(session, &protos.ReadyTos{Ready: true})
+	}
+	q.drain()
+	return g, q, c, sessions
+}
+
+// newWaitingGame 创建一个total个座位的房间，robots个easy机器人先入座，然后humans个玩家入座，都还没有准备
+func newWaitingGame(t *testing.T, total, robots, humans int, rules Rules) (*Game, *testQueue, *testClock, []*testSession) {
+	var strategies []Strategy
+	for range robots {
+		strategy, err := NewStrategy("easy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		strategies = append(strategies, strategy)
+	}
+	q := &testQueue{check: func() {}}
+	c := &testClock{now: time.Unix(0, 0)}
+	g := NewGame(q, total, strategies, rules)
+	g.Clock = c
+	g.InitialSeed = 1
+	g.Recording = false
+	var sessions []*testSession
+	for i := range humans {
+		session := &testSession{id: int64(i + 1)}
+		if !g.Join(session, Identity{Name: "test"}) {
+			t.Fatal("入座失败")
+		}
+		sessions = append(sessions, session)
+	}
+	return g, q, c, sessions
+}
+
+// testSession 把发给玩家的消息都记下来
+type testSession struct {
+	id   int64
+	sent []interface{}
+}
+
+func (s *testSession) Raw() interface{}     { return nil }
+func (s *testSession) Peer() cellnet.Peer   { return nil }
+func (s *testSession) Send(msg interface{}) { s.sent = append(s.sent, msg) }
+func (s *testSession) Close()               {}
+func (s *testSession) ID() int64            { return s.id }
+
+func TestReleaseSeatAfterReconnect(t *testing.T) {
+	g, _, _, sessions := newTestGame(t, 1, 1, Rules{})
+	if g.Phase() != PhasePlaying {
+		t.Fatalf("坐满并且准备好后应该开局，现在是%s", g.Phase())
+	}
+	token, seq, ok := g.Disconnect(sessions[0])
+	if !ok || seq != 1 {
+		t.Fatalf("第一次断线应该保留座位，ok=%v seq=%d", ok, seq)
+	}
+	session := &testSession{id: 2}
+	if !g.Reconnect(session, Identity{}, token) {
+		t.Fatal("断线重连失败")
+	}
+	if _, seq, _ = g.Disconnect(session); seq != 2 {
+		t.Fatalf("第二次断线的seq应该是2，实际是%d", seq)
+	}
+	if g.ReleaseSeat(token, 1) {
+		t.Error("第一次断线的计时器已经过期，不应该让出座位")
+	}
+	if !g.ReleaseSeat(token, 2) {
+		t.Error("第二次断线超时应该让出座位")
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	tests := []struct {
+		name     string
+		prepare  func(g *Game, token string) string // 断线之后做的事，返回重连用的凭证
+		identity Identity
+		ok       bool
+	}{
+		{"原来的凭证", func(g *Game, token string) string { return token }, Identity{Id: "alice"}, true},
+		{"错误的凭证", func(g *Game, token string) string { return token + "x" }, Identity{Id: "alice"}, false},
+		{"别人登录的账号", func(g *Game, token string) string { return token }, Identity{Id: "bob"}, false},
+		{"座位已经让出", func(g *Game, token string) string { g.ReleaseSeat(token, 1); return token }, Identity{Id: "alice"}, false},
+		{"没有断线的玩家", func(g *Game, _ string) string { return g.humanMap[2].token }, Identity{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, _, sessions := newTestGame(t, 1, 2, Rules{})
+			g.humanMap[sessions[0].ID()].identity.Id = "alice"
+			token, _, ok := g.Disconnect(sessions[0])
+			if !ok {
+				t.Fatal("开局后断线应该保留座位")
+			}
+			session := &testSession{id: 3}
+			if got := g.Reconnect(session, tt.identity, tt.prepare(g, token)); got != tt.ok {
+				t.Fatalf("Reconnect() = %v，应该是%v", got, tt.ok)
+			}
+			if !tt.ok {
+				return
+			}
+			g.Resync(session)
+			player := g.humanMap[session.ID()]
+			if player == nil || player.Session != session {
+				t.Fatal("重连后应该回到原来的座位上")
+			}
+			var state *protos.GameStateToc
+			for _, msg := range session.sent {
+				if s, ok := msg.(*protos.GameStateToc); ok {
+					state = s
+				}
+			}
+			if state == nil || !state.Playing || len(state.HandCard) != player.CardCount() {
+				t.Errorf("重连后应该收到完整的局面，实际收到%v", state)
+			}
+		})
+	}
+}
+
+// testQueue 同步的事件队列，每执行一个事件就调用一次check
+type testQueue struct {
+	events []func()
+	check  func()
+}
+
+func (q *testQueue) StartLoop() cellnet.EventQueue { return q }
+func (q *testQueue) StopLoop() cellnet.EventQueue  { return q }
+func (q *testQueue) Wait()                         {}
+func (q *testQueue) EnableCapturePanic(bool)       {}
+
+func (q *testQueue) Post(callback func()) {
+	q.events = append(q.events, callback)
+}
+
+func (q *testQueue) drain() {
+	for len(q.events) > 0 {
+		callback := q.events[0]
+		q.events = q.events[1:]
+		callback()
+		q.check()
+	}
+}
+
+// testClock 虚拟时钟，由advance拨到下一个定时器到期的时候
+type testClock struct {
+	now    time.Time
+	timers []*testTimer
+}
+
+type testTimer struct {
+	when    time.Time
+	f       func()
+	stopped bool
+}
+
+func (t *testTimer) Stop() bool {
+	stopped := t.stopped
+	t.stopped = true
+	return !stopped
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) AfterFunc(d time.Duration, f func()) Timer {
+	t := &testTimer{when: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (c *testClock) advance() bool {
+	next := -1
+	for i, t := range c.timers {
+		if !t.stopped && (next < 0 || t.when.Before(c.timers[next].when)) {
+			next = i
+		}
+	}
+	if next < 0 {
+		return false
+	}
+	t := c.timers[next]
+	c.timers = append(c.timers[:next], c.timers[next+1:]...)
+	t.stopped = true
+	c.now = t.when
+	t.f()
+	return true
+}
+
+func TestRejectedJumpInKeepsTurn(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       Rules
+		lastCard    ICard
+		jumpIn      ICard // 和最后打出的牌完全相同的另一张牌
+		pendingDraw int
+		args        []uint32
+		want        protos.ErrorCode
+	}{
+		{"7-0规则下的7没有指定交换的玩家", Rules{JumpIn: true, SevenZero: true},
+			newNumberCard(1000, uint32(ColorRed), 7), newNumberCard(1001, uint32(ColorRed), 7), 0, []uint32{0}, protos.ErrorCode_invalid_target},
+		{"不能叠加时抢+2", Rules{JumpIn: true},
+			newPlus2Card(1000, uint32(ColorRed)), newPlus2Card(1001, uint32(ColorRed)), 2, nil, protos.ErrorCode_must_stack_or_draw},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, _, _ := newTestGame(t, 0, 2, tt.rules)
+			whoseTurn := g.WhoseTurn
+			other := g.Players[(whoseTurn+1)%2].base()
+			g.LastCard, g.WantColor, g.PendingDraw = tt.lastCard, tt.lastCard.Color(), tt.pendingDraw
+			other.cards[tt.jumpIn.Id()] = tt.jumpIn
+			if code := other.PlayCard(tt.jumpIn.Id(), tt.args...); code != tt.want {
+				t.Fatalf("抢牌应该失败：%s，实际是%s", tt.want, code)
+			}
+			if g.WhoseTurn != whoseTurn {
+				t.Errorf("抢牌失败后回合不应该变，原来是%d号，现在是%d号", whoseTurn, g.WhoseTurn)
+			}
+		})
+	}
+}
+
+func TestCatchUnoAfterSwap(t *testing.T) {
+	tests := []struct {
+		name  string
+		leave bool // 0号交换手牌之前离开，由机器人接管座位
+	}{
+		{"交换手牌", false},
+		{"机器人接管后交换手牌", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, _, sessions := newTestGame(t, 0, 3, Rules{SevenZero: true})
+			forgetful := g.Players[0].base()
+			catcher := g.Players[2].base()
+			// 0号只剩一张牌却没有喊UNO，之后和1号交换了手牌
+			forgetful.cards = map[uint32]ICard{1000: newNumberCard(1000, uint32(ColorRed), 1)}
+			g.unoVulnerable = forgetful.location
+			if tt.leave {
+				g.Leave(sessions[0])
+				if _, ok := g.Players[0].(*RobotPlayer); !ok {
+					t.Fatal("0号离开后应该由机器人接管")
+				}
+			}
+			count := g.Players[1].CardCount()
+			g.swapCards(g.Players[0], g.Players[1])
+			if code := catcher.CatchUno(0); code != protos.ErrorCode_catch_uno_failed {
+				t.Fatalf("交换手牌后0号已经不止一张牌了，不应该被抓，实际是%s", code)
+			}
+			if g.Players[0].CardCount() != count {
+				t.Errorf("0号不应该被罚摸牌，应该有%d张牌，现在有%d张牌", count, g.Players[0].CardCount())
+			}
+		})
+	}
+}
+
+func TestDecisionWindows(t *testing.T) {
+	g, _, _, _ := newTestGame(t, 0, 3, Rules{ChallengePlus4: true})
+	player := g.Players[g.WhoseTurn].base()
+	drawn := newNumberCard(1000, uint32(ColorRed), 1)
+
+	// 别人忘记喊UNO的窗口关闭时，不影响刚摸到的牌
+	g.drawnCard = drawn
+	g.unoVulnerable = player.GetNextPlayer(1).Location()
+	g.closeUnoWindow(player)
+	if g.unoVulnerable != -1 || g.drawnCard != drawn {
+		t.Fatalf("closeUnoWindow只应该关闭抓UNO的窗口，unoVulnerable=%v drawnCard=%v", g.unoVulnerable, g.drawnCard)
+	}
+
+	// 换人之后刚摸到的牌作废
+	g.NextPlayer(0)
+	if g.drawnCard != nil {
+		t.Fatal("换人之后刚摸到的牌应该作废")
+	}
+
+	// 打出+4之后轮到下家，等他决定是否质疑，决定之后质疑作废
+	plus4 := newPlus4Card(1001)
+	player = g.Players[g.WhoseTurn].base()
+	plus4.Execute(g, player, uint32(ColorBlue))
+	challenged := player.GetNextPlayer(1).base()
+	if g.plus4Challenge == nil || g.WhoseTurn != challenged.location {
+		t.Fatalf("打出+4之后应该等%d号决定是否质疑，plus4Challenge=%v whoseTurn=%d", challenged.location, g.plus4Challenge, g.WhoseTurn)
+	}
+	if code := challenged.ChallengePlus4(false); code != protos.ErrorCode_success {
+		t.Fatalf("不质疑应该成功，实际是%s", code)
+	}
+	if g.plus4Challenge != nil {
+		t.Error("决定是否质疑之后，+4质疑应该作废")
+	}
+}
+
+func TestRoundOverScoring(t *testing.T) {
+	tests := []struct {
+		name        string
+		last        ICard
+		pendingDraw int
+		wantDraw    int // 下家在结算之前要摸的牌数
+	}{
+		{"数字牌", newNumberCard(1000, uint32(ColorRed), 5), 0, 0},
+		{"跳过", newSkipCard(1000, uint32(ColorRed)), 0, 0},
+		{"+2", newPlus2Card(1000, uint32(ColorRed)), 0, 2},
+		{"叠加的+2", newPlus2Card(1000, uint32(ColorRed)), 2, 4},
+		{"+4", newPlus4Card(1000), 0, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, _, _ := newTestGame(t, 0, 3, Rules{StackPlus2: true})
+			winner := g.Players[g.WhoseTurn].base()
+			next := winner.GetNextPlayer(1).base()
+			other := next.GetNextPlayer(1).base()
+			winner.cards = map[uint32]ICard{tt.last.Id(): tt.last}
+			next.cards = map[uint32]ICard{1001: newNumberCard(1001, uint32(ColorBlue), 9)}
+			other.cards = map[uint32]ICard{1002: newWildCard(1002), 1003: newReverseCard(1003, uint32(ColorGreen))}
+			g.LastCard, g.WantColor, g.PendingDraw = newPlus2Card(1004, uint32(ColorRed)), ColorRed, tt.pendingDraw
+			if code := winner.PlayCard(tt.last.Id(), uint32(ColorRed)); code != protos.ErrorCode_success {
+				t.Fatalf("出牌失败：%s", code)
+			}
+			if g.Phase() != PhaseRoundOver {
+				t.Fatalf("打出最后一张牌后这一局应该结束，现在是%s", g.Phase())
+			}
+			if len(next.cards) != 1+tt.wantDraw {
+				t.Fatalf("下家应该先摸%d张牌，现在有%d张牌", tt.wantDraw, len(next.cards))
+			}
+			want := 50 + 20
+			for _, card := range next.cards {
+				want += card.Score()
+			}
+			if winner.score != want {
+				t.Errorf("赢家应该得到%d分，实际是%d分", want, winner.score)
+			}
+		})
+	}
+}
+
+func TestRestartBackToWaitingStopsTurnTimer(t *testing.T) {
+	setConfig(t, "turn.timeout", 30)
+	g, _, _, sessions := newTestGame(t, 1, 2, Rules{})
+	// 重新发一局带记录的牌
+	g.Recording = true
+	g.start()
+	generation := g.Generation()
+	// 轮到最后一个座位的玩家，他的回合正在计时
+	g.WhoseTurn = 2
+	g.NextPlayer(0)
+	if g.turnTimer == nil {
+		t.Fatal("玩家的回合应该在计时")
+	}
+	// 1号玩家离开，由机器人接管。2号玩家投票重开，托管的机器人让出座位后人数不够，回到等待开局
+	g.Leave(sessions[0])
+	g.Handle(sessions[1], &protos.RestartGameTos{})
+	if g.Phase() != PhaseWaiting {
+		t.Fatalf("人数不够时应该回到等待开局，现在是%s", g.Phase())
+	}
+	if g.turnTimer != nil {
+		t.Error("回到等待开局时应该停止计时")
+	}
+	if g.Generation() == generation {
+		t.Error("回到等待开局时，上一局还没执行的回调应该过期")
+	}
+	if g.record != nil {
+		t.Error("回到等待开局时，上一局的记录应该作废")
+	}
+	g.Stop()
+}
+
+// TestFirstFlipWild 翻出的第一张牌是变色牌时，任何颜色都可以出，不能沿用上一局要出的颜色
+func TestFirstFlipWild(t *testing.T) {
+	g, _, _, _ := newTestGame(t, 1, 1, Rules{})
+	for range 1000 {
+		g.WantColor = ColorRed
+		g.start()
+		if _, ok := g.LastCard.(*cardWild); ok && len(g.Deck.discardPile) == 1 {
+			if g.WantColor != ColorBlack {
+				t.Fatalf("第一张牌是变色牌，要出的颜色应该不限，实际是%s", g.WantColor)
+			}
+			return
+		}
+	}
+	t.Fatal("一直没有翻出变色牌")
+}
+
+func TestActionsOutsidePlaying(t *testing.T) {
+	actions := []struct {
+		name string
+		msg  interface{}
+	}{
+		{"摸牌", &protos.DiscardCardTos{CardId: 0}},
+		{"出牌", &protos.DiscardCardTos{CardId: 1}},
+		{"不出", &protos.PassTos{}},
+		{"喊UNO", &protos.CallUnoTos{}},
+		{"抓UNO", &protos.CatchUnoTos{PlayerId: 1}},
+		{"质疑", &protos.ChallengePlus4Tos{Challenge: true}},
+	}
+	lastError := func(s *testSession) protos.ErrorCode {
+		for i := len(s.sent) - 1; i >= 0; i-- {
+			if msg, ok := s.sent[i].(*protos.ErrorToc); ok {
+				return msg.Code
+			}
+		}
+		return protos.ErrorCode_success
+	}
+
+	t.Run("等待开局", func(t *testing.T) {
+		// 只有一个玩家的两人房间，没有牌堆也没有手牌
+		g := NewGame(&testQueue{check: func() {}}, 2, nil, Rules{})
+		g.Clock = &testClock{now: time.Unix(0, 0)}
+		sessions := []*testSession{{id: 1}}
+		g.Join(sessions[0], Identity{Name: "test"})
+		for _, action := range actions {
+			sessions[0].sent = nil
+			g.Handle(sessions[0], action.msg)
+			if code := lastError(sessions[0]); code != protos.ErrorCode_not_playing {
+				t.Errorf("%s：应该返回not_playing，实际是%s", action.name, code)
+			}
+		}
+		if code := g.Players[0].base().PlayCard(0); code != protos.ErrorCode_not_playing {
+			t.Errorf("直接摸牌也应该返回not_playing，实际是%s", code)
+		}
+	})
+
+	t.Run("一局结束", func(t *testing.T) {
+		g, _, _, sessions := newTestGame(t, 0, 2, Rules{JumpIn: true})
+		winner := g.Players[g.WhoseTurn].base()
+		loser := winner.GetNextPlayer(1).base()
+		last := newNumberCard(1000, uint32(ColorRed), 5)
+		winner.cards = map[uint32]ICard{last.Id(): last}
+		loser.cards[1001] = newNumberCard(1001, uint32(ColorRed), 5)
+		g.LastCard, g.WantColor, g.PendingDraw = newNumberCard(1002, uint32(ColorRed), 1), ColorRed, 0
+		if code := winner.PlayCard(last.Id()); code != protos.ErrorCode_success || g.Phase() != PhaseRoundOver {
+			t.Fatalf("打出最后一张牌后应该结束这一局，code=%s phase=%s", code, g.Phase())
+		}
+		g.unoVulnerable = winner.location
+		whoseTurn, score := g.WhoseTurn, winner.score
+		for _, session := range sessions {
+			for _, action := range actions {
+				session.sent = nil
+				g.Handle(session, action.msg)
+				if code := lastError(session); code != protos.ErrorCode_not_playing {
+					t.Errorf("%s：应该返回not_playing，实际是%s", action.name, code)
+				}
+			}
+		}
+		// 抢牌和抓UNO也不能绕过检查
+		if code := loser.PlayCard(1001); code != protos.ErrorCode_not_playing {
+			t.Errorf("一局结束后抢牌应该返回not_playing，实际是%s", code)
+		}
+		if code := loser.CatchUno(winner.location); code != protos.ErrorCode_not_playing {
+			t.Errorf("一局结束后抓UNO应该返回not_playing，实际是%s", code)
+		}
+		if g.WhoseTurn != whoseTurn || winner.score != score {
+			t.Errorf("一局结束后回合和得分都不应该变，回合%d->%d，得分%d->%d", whoseTurn, g.WhoseTurn, score, winner.score)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// Match 一场比赛，由若干局组成，直到有人的总分达到目标分数。每个玩家的总分记在玩家身上
+type Match struct {
+	TargetScore int // 目标分数，为0表示不计分，每一局都是单独的一场比赛
+	Round       int // 现在是本场比赛的第几局
+	over        bool
+}
+
+// newRound 开始新的一局。如果上一场比赛已经结束了，所有人的总分清零，开始新的一场比赛
+func (m *Match) newRound(players []IPlayer) {
+	if m.over {
+		for _, player := range players {
+			player.base().score = 0
+		}
+		m.Round = 0
+		m.over = false
+	}
+	m.Round++
+}
+
+// finalDraw 最后打出的是+2或+4时，下家先摸牌（连同之前累积的罚摸牌），摸到的牌也算进赢家的得分。
+// 这张牌的其它效果不再执行，最后一张+4也不能质疑
+func (p *basePlayer) finalDraw(card ICard) {
+	var count int
+	switch card.(type) {
+	case *cardPlus2:
+		count = 2
+	case *cardPlus4:
+		count = 4
+	default:
+		return
+	}
+	count += p.game.PendingDraw
+	p.game.PendingDraw = 0
+	logger.Info(fmt.Sprintf("最后打出的是%s，下家先摸%d张牌", card, count))
+	p.GetNextPlayer(1).Draw(count)
+}
+
+// roundOver 一局结束，赢家得到其他所有玩家剩下的手牌的分数，然后准备开始下一局
+func (game *Game) roundOver(winner *basePlayer) {
+	logger.Info(fmt.Sprintf("%d号玩家获胜", winner.location))
+	game.stopTurnTimer()
+	game.setPhase(PhaseRoundOver)
+	points := 0
+	for _, player := range game.Players {
+		player.ForeachCards(func(card ICard) bool {
+			points += card.Score()
+			return true
+		})
+	}
+	winner.score += points
+	logger.Info(fmt.Sprintf("第%d局，%d号玩家得到%d分，总分%d分", game.Match.Round, winner.location, points, winner.score))
+	game.recordEvent(Event{Type: EventRoundOver, Player: winner.location, Count: points})
+	game.saveRecord()
+	for _, player := range game.audience() {
+		player.NotifyWin(winner.location)
+		player.NotifyRoundResult(winner.location, points)
+	}
+	if game.Match.TargetScore <= 0 || winner.score >= game.Match.TargetScore {
+		game.Match.over = true
+		if game.Match.TargetScore > 0 {
+			logger.Info(fmt.Sprintf("%d号玩家的总分达到%d分，赢得了比赛", winner.location, game.Match.TargetScore))
+			for _, player := range game.audience() {
+				player.NotifyMatchResult(winner.location)
+			}
+		}
+	}
+	logger.Info("游戏将在10秒后重新开始。。。")
+	generation := game.generation
+	game.Clock.AfterFunc(time.Second*10, func() {
+		game.Post(func() {
+			// 这期间投票重开了或者房间解散了，就不要再开一局
+			if game.phase == PhaseRoundOver && game.generation == generation && game.HumanCount() > 0 {
+				game.start()
+			}
+		})
+	})
+}
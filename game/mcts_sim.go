@@ -0,0 +1,298 @@
+package game
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// simMove 模拟对局中的一步行动。花色和点数都相同的牌不区分，这样不同的抽样结果可以共用同一棵搜索树
+type simMove struct {
+	kind      MoveKind // 只会是MovePlay、MoveDraw或MovePass
+	color     Color
+	number    uint32
+	wantColor Color
+}
+
+// simState 搜索时模拟的对局。规则按房规做了简化：不考虑抢牌、喊UNO和+4质疑（下家总是不质疑），
+// 7-0规则下的7总是和手牌最少的玩家交换，除了搜索开始时的那一步，摸到能打出的牌总是打出
+type simState struct {
+	rules   Rules
+	hands   [][]ICard
+	deck    []ICard
+	discard []ICard // 弃牌堆，不包括last
+	last    ICard
+	want    Color
+	dir     bool
+	turn    int
+	pending int
+	drawn   ICard // 刚摸到的能打出的牌，正在决定是否打出
+	winner  int   // 打完手牌的玩家，还没有人打完则为-1
+	random  *rand.Rand
+}
+
+// determinize 从view的视角，把看不到的牌随机分给其他玩家，剩下的作为牌堆，生成一个可能的局面
+func determinize(v *View, random *rand.Rand) *simState {
+	s := &simState{
+		rules:   v.Rules,
+		hands:   make([][]ICard, len(v.HandCounts)),
+		last:    v.LastCard,
+		want:    v.WantColor,
+		dir:     v.Dir,
+		turn:    v.WhoseTurn,
+		pending: v.PendingDraw,
+		drawn:   v.DrawnCard,
+		winner:  -1,
+		random:  random,
+	}
+	if n := len(v.DiscardPile); n > 0 && v.DiscardPile[n-1] == v.LastCard {
+		s.discard = slices.Clone(v.DiscardPile[:n-1])
+	} else {
+		s.discard = slices.Clone(v.DiscardPile)
+	}
+	unseen := slices.Clone(v.Unseen)
+	random.Shuffle(len(unseen), func(i, j int) {
+		unseen[i], unseen[j] = unseen[j], unseen[i]
+	})
+	for location, count := range v.HandCounts {
+		if location == v.Location {
+			s.hands[location] = slices.Clone(v.Hand)
+			continue
+		}
+		count = min(count, len(unseen))
+		s.hands[location] = slices.Clone(unseen[:count])
+		unseen = unseen[count:]
+	}
+	s.deck = unseen
+	return s
+}
+
+// after 按出牌的顺序，从location往后数n个座位的座位号
+func (s *simState) after(location, n int) int {
+	if s.dir {
+		n = -n
+	}
+	total := len(s.hands)
+	return ((location+n)%total + total) % total
+}
+
+// canPlay 和各种牌的CanPlay判断相同，只是不需要参数
+func (s *simState) canPlay(hand []ICard, card ICard) bool {
+	if s.pending > 0 {
+		switch card.Number() {
+		case 12:
+			return s.rules.StackPlus2 && s.last.Number() == 12
+		case 14:
+			return s.rules.StackPlus4
+		}
+		return false
+	}
+	switch card.Number() {
+	case 13:
+		return true
+	case 14:
+		if s.rules.ChallengePlus4 {
+			return true
+		}
+		for _, c := range hand {
+			if c.Number() != 14 && s.canPlay(hand, c) {
+				return false
+			}
+		}
+		return true
+	}
+	return s.want == ColorBlack || s.want == card.Color() || s.last.Number() == card.Number()
+}
+
+// legalMoves 当前轮到的玩家所有可以选择的行动
+func (s *simState) legalMoves() []simMove {
+	if s.drawn != nil {
+		moves := s.playMoves(s.drawn, nil)
+		if !s.rules.ForcePlay {
+			moves = append(moves, simMove{kind: MovePass})
+		}
+		return moves
+	}
+	moves := []simMove{{kind: MoveDraw}}
+	hand := s.hands[s.turn]
+	for _, card := range hand {
+		if s.canPlay(hand, card) {
+			moves = s.playMoves(card, moves)
+		}
+	}
+	return moves
+}
+
+// playMoves 把打出card的所有行动加到moves中，同样的牌只加一次，黑牌每种颜色各算一种行动
+func (s *simState) playMoves(card ICard, moves []simMove) []simMove {
+	move := simMove{kind: MovePlay, color: card.Color(), number: card.Number()}
+	if card.Color() != ColorBlack {
+		if !slices.Contains(moves, move) {
+			moves = append(moves, move)
+		}
+		return moves
+	}
+	for move.wantColor = ColorRed; move.wantColor <= ColorBlue; move.wantColor++ {
+		if !slices.Contains(moves, move) {
+			moves = append(moves, move)
+		}
+	}
+	return moves
+}
+
+// randomMove 模拟时随机选择一步行动，能出牌就不摸牌，黑牌选择手里最多的颜色
+func (s *simState) randomMove() simMove {
+	if s.drawn != nil {
+		return s.colorMove(s.drawn)
+	}
+	hand := s.hands[s.turn]
+	var playable []ICard
+	for _, card := range hand {
+		if s.canPlay(hand, card) {
+			playable = append(playable, card)
+		}
+	}
+	if len(playable) == 0 {
+		return simMove{kind: MoveDraw}
+	}
+	return s.colorMove(playable[s.random.Intn(len(playable))])
+}
+
+func (s *simState) colorMove(card ICard) simMove {
+	move := simMove{kind: MovePlay, color: card.Color(), number: card.Number()}
+	if card.Color() == ColorBlack {
+		counts := make([]int, ColorBlue+1)
+		for _, c := range s.hands[s.turn] {
+			counts[c.Color()]++
+		}
+		move.wantColor = ColorRed
+		for color := ColorGreen; color <= ColorBlue; color++ {
+			if counts[color] > counts[move.wantColor] {
+				move.wantColor = color
+			}
+		}
+	}
+	return move
+}
+
+// apply 当前轮到的玩家执行move，move必须是合法的
+func (s *simState) apply(move simMove) {
+	switch move.kind {
+	case MovePass:
+		s.drawn = nil
+		s.turn = s.after(s.turn, 1)
+	case MoveDraw:
+		s.drawCard()
+	case MovePlay:
+		s.play(move)
+	}
+}
+
+func (s *simState) drawCard() {
+	if s.pending > 0 {
+		s.draw(s.turn, s.pending)
+		s.pending = 0
+		s.turn = s.after(s.turn, 1)
+		return
+	}
+	for {
+		cards := s.draw(s.turn, 1)
+		if len(cards) == 0 {
+			break
+		}
+		if s.canPlay(s.hands[s.turn], cards[0]) {
+			s.play(s.colorMove(cards[0]))
+			return
+		}
+		if !s.rules.DrawUntilPlayable {
+			break
+		}
+	}
+	s.turn = s.after(s.turn, 1)
+}
+
+func (s *simState) draw(location, count int) []ICard {
+	if count > len(s.deck) {
+		s.deck = append(s.deck, s.discard...)
+		s.discard = nil
+		s.random.Shuffle(len(s.deck), func(i, j int) {
+			s.deck[i], s.deck[j] = s.deck[j], s.deck[i]
+		})
+	}
+	count = min(count, len(s.deck))
+	cards := s.deck[:count]
+	s.deck = s.deck[count:]
+	s.hands[location] = append(s.hands[location], cards...)
+	return cards
+}
+
+func (s *simState) play(move simMove) {
+	hand := s.hands[s.turn]
+	index := slices.IndexFunc(hand, func(card ICard) bool {
+		return card.Color() == move.color && card.Number() == move.number
+	})
+	card := hand[index]
+	s.hands[s.turn] = slices.Delete(hand, index, index+1)
+	s.drawn = nil
+	s.discard = append(s.discard, s.last)
+	s.last = card
+	s.want = card.Color()
+	if card.Color() == ColorBlack {
+		s.want = move.wantColor
+	}
+	if len(s.hands[s.turn]) == 0 {
+		s.winner = s.turn
+		return
+	}
+	switch card.Number() {
+	case 7:
+		if s.rules.SevenZero {
+			target := -1
+			for location, hand := range s.hands {
+				if location != s.turn && (target < 0 || len(hand) < len(s.hands[target])) {
+					target = location
+				}
+			}
+			s.hands[s.turn], s.hands[target] = s.hands[target], s.hands[s.turn]
+		}
+		s.turn = s.after(s.turn, 1)
+	case 0:
+		if s.rules.SevenZero {
+			hands := make([][]ICard, len(s.hands))
+			for location, hand := range s.hands {
+				hands[s.after(location, 1)] = hand
+			}
+			s.hands = hands
+		}
+		s.turn = s.after(s.turn, 1)
+	case 10:
+		s.turn = s.after(s.turn, 2)
+	case 11:
+		s.dir = !s.dir
+		s.turn = s.after(s.turn, 1)
+	case 12:
+		if s.rules.StackPlus2 || s.rules.StackPlus4 {
+			s.addPending(2)
+		} else {
+			s.attack(2)
+		}
+	case 14:
+		if s.rules.StackPlus4 && (!s.rules.ChallengePlus4 || s.pending > 0) {
+			s.addPending(4)
+		} else {
+			s.attack(4)
+		}
+	default:
+		s.turn = s.after(s.turn, 1)
+	}
+}
+
+func (s *simState) addPending(count int) {
+	s.pending += count
+	s.turn = s.after(s.turn, 1)
+}
+
+// attack 下家摸count张牌，并且跳过他的回合
+func (s *simState) attack(count int) {
+	s.draw(s.after(s.turn, 1), count)
+	s.turn = s.after(s.turn, 2)
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"fmt"
+	"github.com/CuteReimu/uno-server/protos"
+)
+
+// Phase 房间所处的阶段。只能按 等待->发牌->进行中->结束->发牌... 的顺序切换，座位没坐满时回到等待
+type Phase int
+
+const (
+	PhaseWaiting   Phase = iota // 等待玩家入座和准备
+	PhaseDealing                // 正在洗牌发牌
+	PhasePlaying                // 一局正在进行
+	PhaseRoundOver              // 一局结束，等待下一局开始
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseWaiting:
+		return "等待开局"
+	case PhaseDealing:
+		return "发牌"
+	case PhasePlaying:
+		return "进行中"
+	case PhaseRoundOver:
+		return "一局结束"
+	default:
+		return fmt.Sprintf("未知阶段(%d)", int(p))
+	}
+}
+
+func (p Phase) ToProto() protos.GamePhase {
+	return protos.GamePhase(p)
+}
+
+// Phase 房间所处的阶段
+func (game *Game) Phase() Phase {
+	return game.phase
+}
+
+// Generation 开过的局数，每次发牌时加一。延迟执行的回调要先记下它，执行时不一致说明已经是新的一局了
+func (game *Game) Generation() int {
+	return game.generation
+}
+
+// setPhase 切换阶段，并通知所有玩家和观战者
+func (game *Game) setPhase(phase Phase) {
+	logger.Info(fmt.Sprintf("第%d局：%s -> %s", game.generation, game.phase, phase))
+	game.phase = phase
+	for _, player := range game.audience() {
+		player.NotifyPhase(phase, game.generation)
+	}
+}
@@ -2,35 +2,78 @@ package game
 
 import (
 	"fmt"
-	"time"
+	"github.com/CuteReimu/uno-server/protos"
+	"math/rand"
 )
 
+// Identity 玩家的身份，登录后确定，断线重连后不变
+type Identity struct {
+	Id     string `json:"id,omitempty"`     // 稳定的玩家ID，机器人为空
+	Name   string `json:"name"`             // 显示的名字
+	Avatar uint32 `json:"avatar,omitempty"` // 头像ID，为0表示默认头像
+}
+
 type IPlayer interface {
 	Init(game *Game, location int)
 	Location() int
+	Identity() Identity
 	NotifyAddHandCard(card ...ICard)
 	NotifyOtherAddHandCard(location int, count int)
 	NotifyDeckNum(count int)
 	NotifyDiscardCard(location int, card ICard, args ...uint32)
 	NotifyTurn(location int, dir bool)
-	PlayCard(cardId uint32, args ...uint32)
+	NotifyPendingDraw(location int, count int)
+	PlayCard(cardId uint32, args ...uint32) protos.ErrorCode
+	CallUno() protos.ErrorCode
+	CatchUno(location int) protos.ErrorCode
+	ChallengePlus4(challenge bool) protos.ErrorCode
+	Pass() protos.ErrorCode
+	NotifyUnoCalled(location int)
+	NotifyUnoCaught(location int, catcher int)
+	NotifyChallengePlus4(location int, target int, success bool)
+	NotifyRevealHand(location int, cards []ICard)
+	NotifyDrawnCard(card ICard, playable bool)
+	NotifyRoundResult(winner int, points int)
+	NotifyMatchResult(winner int)
 	IsWin() bool
 	GetNextPlayer(location int) IPlayer
 	NotifyWin(location int)
-	Draw(count int)
+	NotifyGameState()
+	NotifySeats()
+	NotifyReadyState()
+	NotifyRestartVote(location int, votes int, needed int)
+	NotifyPhase(phase Phase, generation int)
+	NotifyChat(location int, text string, emote protos.Emote)
+	Draw(count int) []ICard
 	ForeachCards(func(card ICard) bool)
+	CardCount() int
+	Score() int
+	base() *basePlayer
 }
 
 type basePlayer struct {
-	game     *Game
-	location int
-	cards    map[uint32]ICard
+	game      *Game
+	location  int
+	cards     map[uint32]ICard
+	unoCalled bool       // 手牌剩一张或两张时是否已经喊了UNO
+	score     int        // 本场比赛的总分
+	random    *rand.Rand // 机器人替这个座位做决定时使用的随机数生成器
 }
 
 func (p *basePlayer) Init(game *Game, location int) {
 	p.game = game
 	p.location = location
 	p.cards = make(map[uint32]ICard)
+	p.unoCalled = false
+}
+
+// Identity 机器人没有玩家ID，按座位号起名字
+func (p *basePlayer) Identity() Identity {
+	return Identity{Name: fmt.Sprintf("%d号机器人", p.location+1)}
+}
+
+func (p *basePlayer) base() *basePlayer {
+	return p
 }
 
 func (p *basePlayer) ForeachCards(f func(card ICard) bool) {
@@ -41,6 +84,14 @@ func (p *basePlayer) ForeachCards(f func(card ICard) bool) {
 	}
 }
 
+func (p *basePlayer) CardCount() int {
+	return len(p.cards)
+}
+
+func (p *basePlayer) Score() int {
+	return p.score
+}
+
 func (p *basePlayer) Location() int {
 	return p.location
 }
@@ -54,6 +105,48 @@ func (p *basePlayer) NotifyOtherAddHandCard(int, int) {
 func (p *basePlayer) NotifyDeckNum(int) {
 }
 
+func (p *basePlayer) NotifyGameState() {
+}
+
+func (p *basePlayer) NotifySeats() {
+}
+
+func (p *basePlayer) NotifyChat(int, string, protos.Emote) {
+}
+
+func (p *basePlayer) NotifyReadyState() {
+}
+
+func (p *basePlayer) NotifyRestartVote(int, int, int) {
+}
+
+func (p *basePlayer) NotifyPhase(Phase, int) {
+}
+
+func (p *basePlayer) NotifyPendingDraw(int, int) {
+}
+
+func (p *basePlayer) NotifyUnoCalled(int) {
+}
+
+func (p *basePlayer) NotifyUnoCaught(int, int) {
+}
+
+func (p *basePlayer) NotifyChallengePlus4(int, int, bool) {
+}
+
+func (p *basePlayer) NotifyRevealHand(int, []ICard) {
+}
+
+func (p *basePlayer) NotifyDrawnCard(ICard, bool) {
+}
+
+func (p *basePlayer) NotifyRoundResult(int, int) {
+}
+
+func (p *basePlayer) NotifyMatchResult(int) {
+}
+
 func (p *basePlayer) NotifyDiscardCard(location int, card ICard, _ ...uint32) {
 	if location == p.location {
 		delete(p.cards, card.Id())
@@ -65,57 +158,254 @@ func (p *basePlayer) NotifyTurn(int, bool) {
 	panic("implement me")
 }
 
-func (p *basePlayer) PlayCard(cardId uint32, args ...uint32) {
+// PlayCard 出牌，cardId为0表示摸牌。args[0]是打出黑牌时选择的颜色，args[1]是打出7时选择交换手牌的玩家。出牌被拒绝时返回原因
+func (p *basePlayer) PlayCard(cardId uint32, args ...uint32) protos.ErrorCode {
+	if code := p.checkPlaying(); code != protos.ErrorCode_success {
+		return code
+	}
 	if p.game.WhoseTurn != p.location {
-		logger.Error("还没到你的回合，不能出牌")
-		return
+		if !p.canJumpIn(cardId) {
+			logger.Error("还没到你的回合，不能出牌")
+			return protos.ErrorCode_not_your_turn
+		}
+		// 先确认这张牌能打出，再抢过回合，否则抢牌失败时回合就停在他身上了
+		if code := p.cards[cardId].CanPlay(p.game, p, args...); code != protos.ErrorCode_success {
+			logger.Error(fmt.Sprint("抢牌失败，你不能打这张牌", p.cards[cardId]), "code", code)
+			return code
+		}
+		logger.Info(fmt.Sprintf("%d号玩家抢牌", p.location))
+		p.game.stopTurnTimer()
+		p.game.WhoseTurn = p.location
+		p.game.drawnCard = nil
+	}
+	if challenge := p.game.plus4Challenge; challenge != nil {
+		if cardId != 0 {
+			logger.Error("要先决定是否质疑上家打出的+4")
+			return protos.ErrorCode_must_answer_challenge
+		}
+		return p.ChallengePlus4(false)
+	}
+	if drawnCard := p.game.drawnCard; drawnCard != nil && drawnCard.Id() != cardId {
+		logger.Error("摸牌后只能打出刚摸到的牌，或者选择不出")
+		return protos.ErrorCode_must_play_drawn_or_pass
 	}
 	if cardId == 0 {
-		p.Draw(1)
-		p.game.NextPlayer(1)
-		return
+		return p.drawCard()
 	}
 	card := p.cards[cardId]
 	if card == nil {
 		logger.Error("你没有这张牌")
-		return
+		return protos.ErrorCode_no_such_card
+	}
+	if code := card.CanPlay(p.game, p, args...); code != protos.ErrorCode_success {
+		logger.Error(fmt.Sprint("你不能打这张牌", card), "code", code)
+		return code
+	}
+	p.game.closeUnoWindow(p)
+	p.game.drawnCard = nil
+	for _, player := range p.game.audience() {
+		player.NotifyDiscardCard(p.location, card, args...)
+	}
+	p.recordDiscard(card, args...)
+	if card.Color() == ColorBlack && p.game.WantColor != ColorBlack {
+		logger.Info(fmt.Sprintf("%d号玩家打出%s，并选择%s", p.location, card, p.game.WantColor))
+	} else {
+		logger.Info(fmt.Sprintf("%d号玩家打出%s", p.location, card))
+	}
+	if p.IsWin() {
+		p.finalDraw(card)
+		p.game.roundOver(p)
+		return protos.ErrorCode_success
+	}
+	if len(p.cards) == 1 && !p.unoCalled {
+		logger.Info(fmt.Sprintf("%d号玩家只剩一张牌，但是没有喊UNO", p.location))
+		p.game.unoVulnerable = p.location
+	}
+	card.Execute(p.game, p, args...)
+	return protos.ErrorCode_success
+}
+
+// recordDiscard 记录出牌，黑牌记录选择的颜色，7-0规则下的7记录交换手牌的玩家
+func (p *basePlayer) recordDiscard(card ICard, args ...uint32) {
+	e := Event{Type: EventDiscard, Player: p.location, Cards: recordCards(card)}
+	if card.Color() == ColorBlack && len(args) > 0 {
+		e.WantColor = Color(args[0])
+	}
+	if p.game.Rules.SevenZero && card.Number() == 7 && len(args) > 1 {
+		e.Target = int(args[1])
 	}
-	if card.CanPlay(p.game, p, args...) {
-		for _, player := range p.game.Players {
-			player.NotifyDiscardCard(p.location, card, args...)
+	p.game.recordEvent(e)
+}
+
+// Pass 摸牌后不出刚摸到的牌，结束回合
+func (p *basePlayer) Pass() protos.ErrorCode {
+	if code := p.checkPlaying(); code != protos.ErrorCode_success {
+		return code
+	}
+	if p.game.drawnCard == nil || p.game.WhoseTurn != p.location {
+		logger.Error("现在不能选择不出")
+		return protos.ErrorCode_cannot_pass
+	}
+	if p.game.Rules.ForcePlay {
+		logger.Error("摸到的牌能打出时必须打出")
+		return protos.ErrorCode_cannot_pass
+	}
+	logger.Info(fmt.Sprintf("%d号玩家摸牌后选择不出", p.location))
+	p.game.recordEvent(Event{Type: EventPass, Player: p.location})
+	p.game.drawnCard = nil
+	p.game.NextPlayer(1)
+	return protos.ErrorCode_success
+}
+
+// CallUno 喊UNO
+func (p *basePlayer) CallUno() protos.ErrorCode {
+	if code := p.checkPlaying(); code != protos.ErrorCode_success {
+		return code
+	}
+	if len(p.cards) > 2 {
+		logger.Error("手牌多于两张，不能喊UNO")
+		return protos.ErrorCode_cannot_call_uno
+	}
+	if p.unoCalled {
+		return protos.ErrorCode_success
+	}
+	logger.Info(fmt.Sprintf("%d号玩家喊了UNO", p.location))
+	p.game.recordEvent(Event{Type: EventUnoCall, Player: p.location})
+	p.unoCalled = true
+	if p.game.unoVulnerable == p.location {
+		p.game.unoVulnerable = -1
+	}
+	for _, player := range p.game.audience() {
+		player.NotifyUnoCalled(p.location)
+	}
+	return protos.ErrorCode_success
+}
+
+// ChallengePlus4 决定是否质疑上家打出的+4。不质疑就摸4张牌并跳过回合；
+// 质疑的话可以看到上家的手牌，质疑成功则上家摸4张牌，自己正常出牌，质疑失败则自己摸6张牌并跳过回合
+func (p *basePlayer) ChallengePlus4(challenge bool) protos.ErrorCode {
+	if code := p.checkPlaying(); code != protos.ErrorCode_success {
+		return code
+	}
+	c := p.game.plus4Challenge
+	if c == nil || p.game.WhoseTurn != p.location {
+		logger.Error("现在没有可以质疑的+4")
+		return protos.ErrorCode_no_challenge
+	}
+	p.game.plus4Challenge = nil
+	p.game.closeUnoWindow(p)
+	if !challenge {
+		logger.Info(fmt.Sprintf("%d号玩家没有质疑+4", p.location))
+		p.Draw(4)
+		p.game.NextPlayer(1)
+		return protos.ErrorCode_success
+	}
+	target := p.game.Players[c.player]
+	var cards []ICard
+	target.ForeachCards(func(card ICard) bool {
+		cards = append(cards, card)
+		return true
+	})
+	p.game.Players[p.location].NotifyRevealHand(c.player, cards)
+	if c.illegal {
+		logger.Info(fmt.Sprintf("%d号玩家质疑%d号玩家打出的+4，质疑成功", p.location, c.player))
+	} else {
+		logger.Info(fmt.Sprintf("%d号玩家质疑%d号玩家打出的+4，质疑失败", p.location, c.player))
+	}
+	p.game.recordEvent(Event{Type: EventChallenge, Player: p.location, Target: c.player, Success: c.illegal})
+	for _, player := range p.game.audience() {
+		player.NotifyChallengePlus4(p.location, c.player, c.illegal)
+	}
+	if c.illegal {
+		target.Draw(4)
+		p.game.NextPlayer(0) // 还是自己的回合，重新通知一遍
+	} else {
+		p.Draw(6)
+		p.game.NextPlayer(1)
+	}
+	return protos.ErrorCode_success
+}
+
+// CatchUno 抓忘记喊UNO的玩家，被抓到的玩家罚摸2张牌。7-0规则下交换手牌之后他可能已经不止一张牌了，就不能再抓
+func (p *basePlayer) CatchUno(location int) protos.ErrorCode {
+	if code := p.checkPlaying(); code != protos.ErrorCode_success {
+		return code
+	}
+	target := p.game.unoVulnerablePlayer()
+	if target == nil || target.location != location || location == p.location || len(target.cards) != 1 {
+		logger.Error("没有抓到忘记喊UNO的玩家")
+		return protos.ErrorCode_catch_uno_failed
+	}
+	logger.Info(fmt.Sprintf("%d号玩家抓到%d号玩家忘记喊UNO", p.location, location))
+	p.game.recordEvent(Event{Type: EventUnoCatch, Player: p.location, Target: location})
+	p.game.unoVulnerable = -1
+	for _, player := range p.game.audience() {
+		player.NotifyUnoCaught(location, p.location)
+	}
+	p.game.Players[location].Draw(2)
+	return protos.ErrorCode_success
+}
+
+// checkPlaying 只有一局正在进行时才能出牌、摸牌、喊UNO、抓UNO和质疑
+func (p *basePlayer) checkPlaying() protos.ErrorCode {
+	if p.game.phase != PhasePlaying {
+		logger.Error(fmt.Sprintf("现在是%s阶段，不能行动", p.game.phase))
+		return protos.ErrorCode_not_playing
+	}
+	return protos.ErrorCode_success
+}
+
+// canJumpIn 按照抢牌的房规，不在自己的回合能否打出这张牌
+func (p *basePlayer) canJumpIn(cardId uint32) bool {
+	card := p.cards[cardId]
+	lastCard := p.game.LastCard
+	if !p.game.Rules.JumpIn || card == nil || lastCard == nil || card.Color() == ColorBlack {
+		return false
+	}
+	return card.Color() == lastCard.Color() && card.Number() == lastCard.Number()
+}
+
+// drawCard 摸牌，按照房规可能要一直摸到能打出的牌为止。摸到的牌能打出时，由玩家决定是否打出，否则直接结束回合。
+// 如果正在累积罚摸牌，则摸掉所有的罚摸牌，并且跳过自己的回合
+func (p *basePlayer) drawCard() protos.ErrorCode {
+	p.game.closeUnoWindow(p)
+	if count := p.game.PendingDraw; count > 0 {
+		p.game.PendingDraw = 0
+		p.Draw(count)
+		p.game.notifyPendingDraw()
+		p.game.NextPlayer(1)
+		return protos.ErrorCode_success
+	}
+	var card ICard
+	for {
+		cards := p.Draw(1)
+		if len(cards) == 0 {
+			break // 牌堆和弃牌堆都没有牌了
 		}
-		if card.Color() == ColorBlack && p.game.WantColor != ColorBlack {
-			logger.Info(fmt.Sprintf("%d号玩家打出%s，并选择%s", p.location, card, p.game.WantColor))
-		} else {
-			logger.Info(fmt.Sprintf("%d号玩家打出%s", p.location, card))
+		card = cards[0]
+		if !p.game.Rules.DrawUntilPlayable || card.CanPlay(p.game, p, p.autoArgs(card)...) == protos.ErrorCode_success {
+			break
 		}
-		if p.IsWin() {
-			logger.Info(fmt.Sprintf("%d号玩家获胜", p.location))
-			for _, player := range p.game.Players {
-				player.NotifyWin(p.location)
-			}
-			logger.Info("游戏将在10秒后重新开始。。。")
-			time.AfterFunc(time.Second*10, func() {
-				p.game.Post(func() {
-					if p.IsWin() {
-						p.game.start()
-					}
-				})
-			})
-			return
+	}
+	if card != nil {
+		playable := card.CanPlay(p.game, p, p.autoArgs(card)...) == protos.ErrorCode_success
+		p.game.Players[p.location].NotifyDrawnCard(card, playable)
+		if playable {
+			p.game.drawnCard = card
+			return protos.ErrorCode_success
 		}
-		card.Execute(p.game, p, args...)
-	} else {
-		logger.Error(fmt.Sprint("你不能打这张牌", card))
 	}
+	p.game.NextPlayer(1)
+	return protos.ErrorCode_success
 }
 
 func (p *basePlayer) IsWin() bool {
 	return len(p.cards) == 0
 }
 
+// GetNextPlayer 按出牌的顺序，从自己往后数location个座位的玩家
 func (p *basePlayer) GetNextPlayer(location int) IPlayer {
-	if !p.game.Dir {
+	if p.game.Dir {
 		location = -location
 	}
 	location += p.location
@@ -129,13 +419,17 @@ func (p *basePlayer) GetNextPlayer(location int) IPlayer {
 func (p *basePlayer) NotifyWin(int) {
 }
 
-func (p *basePlayer) Draw(count int) {
+func (p *basePlayer) Draw(count int) []ICard {
 	cards := p.game.Deck.Draw(count)
 	for _, card := range cards {
 		p.cards[card.Id()] = card
 	}
+	if len(p.cards) > 2 {
+		p.unoCalled = false
+	}
 	logger.Info(fmt.Sprintf("%d号玩家摸了%d张牌, 现在还有%d张牌", p.location, count, len(p.cards)))
-	for _, player := range p.game.Players {
+	p.game.recordEvent(Event{Type: EventDraw, Player: p.location, Cards: recordCards(cards...)})
+	for _, player := range p.game.audience() {
 		if player.Location() == p.Location() {
 			player.NotifyDeckNum(len(p.game.Deck.cards))
 			player.NotifyAddHandCard(cards...)
@@ -144,53 +438,30 @@ func (p *basePlayer) Draw(count int) {
 			player.NotifyOtherAddHandCard(p.location, len(cards))
 		}
 	}
+	return cards
 }
 
-type RobotPlayer struct {
-	basePlayer
-}
-
-func (r *RobotPlayer) NotifyTurn(location int, _ bool) {
-	time.AfterFunc(time.Second/2, func() {
-		r.game.Post(func() {
-			if location != r.location {
-				return
-			}
-			cardId, wantColor := func() (uint32, uint32) {
-				for _, card := range r.cards {
-					if card.Color() != ColorBlack && card.Number() >= 10 {
-						if card.CanPlay(r.game, r) {
-							return card.Id(), 0
-						}
-					}
-				}
-				for _, card := range r.cards {
-					if card.Color() != ColorBlack && card.Number() < 10 {
-						if card.CanPlay(r.game, r) {
-							return card.Id(), 0
-						}
-					}
-				}
-				for _, card := range r.cards {
-					if card.Color() == 0 && card.Number() == 13 {
-						return card.Id(), r.getMaxNumColor()
-					}
-				}
-				for _, card := range r.cards {
-					if card.Color() == 0 && card.Number() == 14 {
-						return card.Id(), r.getMaxNumColor()
-					}
-				}
-				return 0, 0
-			}()
-			r.PlayCard(cardId, wantColor)
-		})
-	})
+// autoArgs 替玩家自动选择打出card时的参数：黑牌选择手里最多的颜色，7-0规则下的7选择手牌最少的玩家交换
+func (p *basePlayer) autoArgs(card ICard) []uint32 {
+	var wantColor uint32
+	if card.Color() == ColorBlack {
+		wantColor = p.getMaxNumColor()
+	}
+	if !p.game.Rules.SevenZero || card.Number() != 7 {
+		return []uint32{wantColor}
+	}
+	var target IPlayer
+	for _, player := range p.game.Players {
+		if player.Location() != p.location && (target == nil || player.CardCount() < target.CardCount()) {
+			target = player
+		}
+	}
+	return []uint32{wantColor, uint32(target.Location())}
 }
 
-func (r *RobotPlayer) getMaxNumColor() uint32 {
+func (p *basePlayer) getMaxNumColor() uint32 {
 	nums := make([]uint32, 5)
-	for _, card := range r.cards {
+	for _, card := range p.cards {
 		nums[card.Color()]++
 	}
 	maxI := 1
@@ -1,31 +1,154 @@
 package game
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/CuteReimu/uno-server/config"
 	"github.com/CuteReimu/uno-server/protos"
 	"github.com/davyxu/cellnet"
+	"maps"
+	"slices"
+	"time"
 )
 
 type HumanPlayer struct {
 	basePlayer
-	cellnet.Session
+	cellnet.Session               // 断线等待重连时为nil
+	identity        Identity      // 玩家的身份
+	token           string        // 断线重连的凭证
+	disconnects     int           // 断线的次数，用来判断重连超时的计时器是不是这一次断线的
+	timeBank        time.Duration // 本局剩下的备用时间
+	chatLimiter     rateLimiter   // 限制发言的频率
+	ready           bool          // 等待开局时是否已经准备好
+	restartVote     bool          // 是否已经投票重开
+}
+
+func newReconnectToken() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// Send 断线期间发给他的消息直接丢弃，重连时会通过 resync 重新同步局面
+func (r *HumanPlayer) Send(msg interface{}) {
+	if r.Session != nil {
+		r.Session.Send(msg)
+	}
+}
+
+func (r *HumanPlayer) Identity() Identity {
+	return r.identity
 }
 
 func (r *HumanPlayer) Init(game *Game, location int) {
 	r.basePlayer.Init(game, location)
+	r.timeBank = time.Duration(config.GlobalConfig.GetInt("turn.time_bank")) * time.Second
+	r.Send(r.initToc())
+}
+
+func (r *HumanPlayer) initToc() *protos.InitToc {
 	msg := &protos.InitToc{
-		PlayerNum: uint32(r.game.TotalPlayerCount),
+		PlayerNum:      uint32(r.game.TotalPlayerCount),
+		ReconnectToken: r.token,
+		Players:        r.identities(),
+	}
+	if debugBuild {
+		msg.Seed = r.game.Seed
+	}
+	return msg
+}
+
+// resync 断线重连或者中途入座后，把当前局面重新发一遍
+func (r *HumanPlayer) resync() {
+	r.Send(r.initToc())
+	r.NotifyGameState()
+}
+
+// NotifyGameState 把完整的局面发给他
+func (r *HumanPlayer) NotifyGameState() {
+	r.Send(r.gameState())
+}
+
+// gameState 从他的视角看到的完整的局面
+func (r *HumanPlayer) gameState() *protos.GameStateToc {
+	msg := &protos.GameStateToc{
+		PlayerNum:  uint32(r.game.TotalPlayerCount),
+		Playing:    r.game.phase == PhasePlaying,
+		Phase:      r.game.phase.ToProto(),
+		Generation: uint32(r.game.generation),
+	}
+	if r.game.Deck != nil {
+		cardIds := slices.Sorted(maps.Keys(r.cards))
+		for _, cardId := range cardIds {
+			msg.HandCard = append(msg.HandCard, cardToProto(r.cards[cardId]))
+		}
+		msg.HandCardNum = make([]uint32, r.game.TotalPlayerCount)
+		for _, player := range r.game.Players {
+			msg.HandCardNum[r.getAlternativeLocation(player.Location())] = uint32(player.CardCount())
+		}
+		if r.game.LastCard != nil {
+			msg.LastCard = cardToProto(r.game.LastCard)
+		}
+		msg.WantColor = uint32(r.game.WantColor)
+		msg.Dir = r.game.Dir
+		msg.WhoseTurn = r.getAlternativeLocation(r.game.WhoseTurn)
+		msg.DeckNum = uint32(len(r.game.Deck.cards))
+		msg.PendingDraw = uint32(r.game.PendingDraw)
+		msg.ChallengePending = r.game.plus4Challenge != nil && r.game.WhoseTurn == r.location
+		if r.game.drawnCard != nil && r.game.WhoseTurn == r.location {
+			msg.DrawnCard = cardToProto(r.game.drawnCard)
+		}
+		msg.Score = r.scores()
+		msg.Round = uint32(r.game.Match.Round)
+		if !r.game.turnDeadline.IsZero() {
+			msg.TurnDeadline = r.game.turnDeadline.UnixMilli()
+		}
+		msg.Players = r.identities()
+	}
+	return msg
+}
+
+// NotifySeats 把所有座位的信息发给他。还没开局时座位号还没有确定，所以都按照在 Game.Players 中的下标计算
+func (r *HumanPlayer) NotifySeats() {
+	if self := slices.Index(r.game.Players, IPlayer(r)); self >= 0 {
+		r.Send(r.seats(self))
+	}
+}
+
+// seats 以self号座位的视角看到的所有座位的信息
+func (r *HumanPlayer) seats(self int) *protos.SeatInfoToc {
+	msg := &protos.SeatInfoToc{PlayerNum: uint32(r.game.TotalPlayerCount)}
+	for location, player := range r.game.Players {
+		identity := player.Identity()
+		seat := &protos.SeatInfo{
+			PlayerId:  uint32((location - self + r.game.TotalPlayerCount) % r.game.TotalPlayerCount),
+			Player:    identityToProto(identity),
+			AvatarId:  identity.Avatar,
+			Connected: true,
+		}
+		switch p := player.(type) {
+		case *RobotPlayer:
+			seat.IsRobot = true
+		case *HumanPlayer:
+			seat.Connected = p.Session != nil
+		}
+		msg.Seats = append(msg.Seats, seat)
+	}
+	return msg
+}
+
+func cardToProto(card ICard) *protos.UnoCard {
+	return &protos.UnoCard{
+		CardId: card.Id(),
+		Color:  uint32(card.Color()),
+		Num:    card.Number(),
 	}
-	r.Send(msg)
 }
 
 func (r *HumanPlayer) NotifyAddHandCard(cards ...ICard) {
 	msg := &protos.DrawCardToc{}
 	for _, card := range cards {
-		msg.Card = append(msg.Card, &protos.UnoCard{
-			CardId: card.Id(),
-			Color:  uint32(card.Color()),
-			Num:    card.Number(),
-		})
+		msg.Card = append(msg.Card, cardToProto(card))
 	}
 	r.Send(msg)
 }
@@ -34,6 +157,7 @@ func (r *HumanPlayer) NotifyOtherAddHandCard(location int, count int) {
 	msg := &protos.OtherAddHandCardToc{
 		PlayerId: r.getAlternativeLocation(location),
 		Num:      uint32(count),
+		Player:   r.identityOf(location),
 	}
 	r.Send(msg)
 }
@@ -47,28 +171,198 @@ func (r *HumanPlayer) NotifyDeckNum(count int) {
 
 func (r *HumanPlayer) NotifyDiscardCard(location int, card ICard, args ...uint32) {
 	r.basePlayer.NotifyDiscardCard(location, card, args...)
+	r.Send(r.discardCardToc(location, card, args...))
+}
+
+func (r *HumanPlayer) discardCardToc(location int, card ICard, args ...uint32) *protos.DiscardCardToc {
 	msg := &protos.DiscardCardToc{
 		PlayerId: r.getAlternativeLocation(location),
-		Card: &protos.UnoCard{
-			CardId: card.Id(),
-			Color:  uint32(card.Color()),
-			Num:    card.Number(),
-		},
+		Card:     cardToProto(card),
+		Player:   r.identityOf(location),
 	}
 	if len(args) > 0 {
 		msg.WantColor = args[0]
 	}
-	r.Send(msg)
+	return msg
 }
 
 func (r *HumanPlayer) NotifyTurn(location int, dir bool) {
+	if r.Session == nil {
+		if location == r.location && config.GlobalConfig.GetBool("reconnect.robot_play") {
+			r.robotPlay(r, defaultStrategy(), location, func() bool { return r.Session == nil })
+		}
+		return
+	}
 	msg := &protos.NotifyTurnToc{
 		PlayerId: r.getAlternativeLocation(location),
 		Dir:      dir,
+		Player:   r.identityOf(location),
+	}
+	if !r.game.turnDeadline.IsZero() {
+		msg.TurnDeadline = r.game.turnDeadline.UnixMilli()
+	}
+	r.Send(msg)
+}
+
+// NotifyError 通知他的操作被拒绝了
+func (r *HumanPlayer) NotifyError(code protos.ErrorCode, cardId uint32) {
+	r.Send(&protos.ErrorToc{
+		Code:   code,
+		CardId: cardId,
+	})
+}
+
+func (r *HumanPlayer) NotifyPendingDraw(location int, count int) {
+	r.Send(&protos.PendingDrawToc{
+		PlayerId: r.getAlternativeLocation(location),
+		Num:      uint32(count),
+		Player:   r.identityOf(location),
+	})
+}
+
+func (r *HumanPlayer) NotifyUnoCalled(location int) {
+	r.Send(&protos.UnoCalledToc{
+		PlayerId: r.getAlternativeLocation(location),
+		Player:   r.identityOf(location),
+	})
+}
+
+func (r *HumanPlayer) NotifyUnoCaught(location int, catcher int) {
+	r.Send(&protos.UnoCaughtToc{
+		PlayerId:  r.getAlternativeLocation(location),
+		CatcherId: r.getAlternativeLocation(catcher),
+		Player:    r.identityOf(location),
+		Catcher:   r.identityOf(catcher),
+	})
+}
+
+func (r *HumanPlayer) NotifyChallengePlus4(location int, target int, success bool) {
+	r.Send(&protos.ChallengePlus4Toc{
+		PlayerId: r.getAlternativeLocation(location),
+		TargetId: r.getAlternativeLocation(target),
+		Success:  success,
+		Player:   r.identityOf(location),
+		Target:   r.identityOf(target),
+	})
+}
+
+func (r *HumanPlayer) NotifyRevealHand(location int, cards []ICard) {
+	msg := &protos.RevealHandToc{
+		PlayerId: r.getAlternativeLocation(location),
+		Player:   r.identityOf(location),
+	}
+	for _, card := range cards {
+		msg.Cards = append(msg.Cards, cardToProto(card))
 	}
 	r.Send(msg)
 }
 
+func (r *HumanPlayer) NotifyChat(location int, text string, emote protos.Emote) {
+	r.Send(&protos.ChatToc{
+		PlayerId: r.getAlternativeLocation(location),
+		Player:   r.identityOf(location),
+		Text:     text,
+		Emote:    emote,
+	})
+}
+
+// NotifyReadyState 和座位信息一样，玩家ID按照在 Game.Players 中的下标计算
+func (r *HumanPlayer) NotifyReadyState() {
+	if self := slices.Index(r.game.Players, IPlayer(r)); self >= 0 {
+		r.Send(r.readyState(self))
+	}
+}
+
+// readyState 以self号座位的视角看到的准备状态
+func (r *HumanPlayer) readyState(self int) *protos.ReadyStateToc {
+	msg := &protos.ReadyStateToc{
+		PlayerNum: uint32(r.game.TotalPlayerCount),
+		SeatedNum: uint32(len(r.game.Players)),
+	}
+	host := r.game.host()
+	for location, p := range r.game.Players {
+		playerId := uint32((location - self + r.game.TotalPlayerCount) % r.game.TotalPlayerCount)
+		switch player := p.(type) {
+		case *RobotPlayer:
+			msg.ReadyIds = append(msg.ReadyIds, playerId)
+			msg.ReadyPlayers = append(msg.ReadyPlayers, identityToProto(player.Identity()))
+		case *HumanPlayer:
+			if player == host {
+				msg.HostId = playerId
+				msg.Host = identityToProto(player.Identity())
+			}
+			if player.ready {
+				msg.ReadyIds = append(msg.ReadyIds, playerId)
+				msg.ReadyPlayers = append(msg.ReadyPlayers, identityToProto(player.Identity()))
+			}
+		}
+	}
+	return msg
+}
+
+func (r *HumanPlayer) NotifyRestartVote(location int, votes int, needed int) {
+	r.Send(&protos.RestartVoteToc{
+		PlayerId: r.getAlternativeLocation(location),
+		Player:   r.identityOf(location),
+		Votes:    uint32(votes),
+		Needed:   uint32(needed),
+	})
+}
+
+func (r *HumanPlayer) NotifyPhase(phase Phase, generation int) {
+	r.Send(&protos.PhaseToc{Phase: phase.ToProto(), Generation: uint32(generation)})
+}
+
+func (r *HumanPlayer) NotifyDrawnCard(card ICard, playable bool) {
+	r.Send(&protos.DrawnCardToc{
+		Card:     cardToProto(card),
+		Playable: playable,
+	})
+}
+
+func (r *HumanPlayer) NotifyRoundResult(winner int, points int) {
+	msg := &protos.RoundResultToc{
+		WinnerId: r.getAlternativeLocation(winner),
+		Round:    uint32(r.game.Match.Round),
+		Points:   uint32(points),
+		Results:  make([]*protos.PlayerRoundResult, r.game.TotalPlayerCount),
+		Winner:   r.identityOf(winner),
+	}
+	for _, player := range r.game.Players {
+		result := &protos.PlayerRoundResult{
+			PlayerId: r.getAlternativeLocation(player.Location()),
+			Score:    uint32(player.Score()),
+			Player:   identityToProto(player.Identity()),
+		}
+		player.ForeachCards(func(card ICard) bool {
+			result.HandCard = append(result.HandCard, cardToProto(card))
+			result.Points += uint32(card.Score())
+			return true
+		})
+		msg.Results[result.PlayerId] = result
+	}
+	r.Send(msg)
+}
+
+func (r *HumanPlayer) NotifyMatchResult(winner int) {
+	r.Send(&protos.MatchResultToc{
+		WinnerId:    r.getAlternativeLocation(winner),
+		TargetScore: uint32(r.game.Match.TargetScore),
+		Score:       r.scores(),
+		Winner:      r.identityOf(winner),
+		Players:     r.identities(),
+	})
+}
+
+// scores 每个玩家的总分，按照相对位置排列
+func (r *HumanPlayer) scores() []uint32 {
+	scores := make([]uint32, r.game.TotalPlayerCount)
+	for _, player := range r.game.Players {
+		scores[r.getAlternativeLocation(player.Location())] = uint32(player.Score())
+	}
+	return scores
+}
+
 func (r *HumanPlayer) IsWin() bool {
 	return len(r.cards) == 0
 }
@@ -76,9 +370,32 @@ func (r *HumanPlayer) IsWin() bool {
 func (r *HumanPlayer) NotifyWin(location int) {
 	r.Send(&protos.NotifyWinToc{
 		PlayerId: r.getAlternativeLocation(location),
+		Player:   r.identityOf(location),
 	})
 }
 
+func identityToProto(identity Identity) *protos.PlayerIdentity {
+	return &protos.PlayerIdentity{UserId: identity.Id, Name: identity.Name}
+}
+
+// identityOf location号座位上的玩家的身份，location不是座位号（例如翻开第一张牌时）则返回nil
+func (r *HumanPlayer) identityOf(location int) *protos.PlayerIdentity {
+	if location < 0 || location >= len(r.game.Players) {
+		return nil
+	}
+	return identityToProto(r.game.Players[location].Identity())
+}
+
+// identities 每个座位上的玩家的身份，按照相对位置排列
+func (r *HumanPlayer) identities() []*protos.PlayerIdentity {
+	identities := make([]*protos.PlayerIdentity, r.game.TotalPlayerCount)
+	// 开局时其他玩家可能还没有 Init ，所以用下标而不是 Location
+	for location, player := range r.game.Players {
+		identities[r.getAlternativeLocation(location)] = identityToProto(player.Identity())
+	}
+	return identities
+}
+
 func (r *HumanPlayer) getAlternativeLocation(location int) uint32 {
 	if location == 99999 {
 		return 99999
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"fmt"
+	"github.com/CuteReimu/uno-server/protos"
+	"time"
+)
+
+type RobotPlayer struct {
+	basePlayer
+	strategy   Strategy
+	substitute bool // 是否是替中途离开的玩家托管的机器人
+}
+
+func (r *RobotPlayer) NotifyTurn(location int, _ bool) {
+	r.robotPlay(r, r.strategy, location, nil)
+}
+
+// robotPlay 延迟一会儿后，按strategy替self出牌。如果那时座位已经换人，或者check返回false，则什么也不做
+func (p *basePlayer) robotPlay(self IPlayer, strategy Strategy, location int, check func() bool) {
+	generation := p.game.generation
+	p.game.Clock.AfterFunc(time.Second/2, func() {
+		p.game.Post(func() {
+			if p.game.phase != PhasePlaying || p.game.generation != generation || location != p.location || p.game.WhoseTurn != p.location || p.game.Players[p.location] != self {
+				return
+			}
+			if check != nil && !check() {
+				return
+			}
+			p.robotAct(strategy)
+		})
+	})
+}
+
+// robotAct 按strategy行动一次。摸到能打出的牌时不会再通知轮到自己，所以接着决定是否打出
+func (p *basePlayer) robotAct(strategy Strategy) {
+	p.applyMove(strategy.ChooseMove(p.view()))
+	if p.game.phase == PhasePlaying && p.game.drawnCard != nil && p.game.WhoseTurn == p.location {
+		p.applyMove(strategy.ChooseMove(p.view()))
+	}
+}
+
+// applyMove 执行策略选择的行动。行动不合法的话，就当作超时处理
+func (p *basePlayer) applyMove(move Move) {
+	if move.CatchUno && p.game.unoVulnerable >= 0 {
+		p.CatchUno(p.game.unoVulnerable)
+	}
+	var code protos.ErrorCode
+	switch move.Kind {
+	case MovePlay:
+		if move.CallUno {
+			p.CallUno()
+		}
+		code = p.PlayCard(move.Card.Id(), uint32(move.WantColor), uint32(move.Target))
+	case MoveDraw:
+		code = p.PlayCard(0)
+	case MovePass:
+		code = p.Pass()
+	case MoveChallenge, MoveAccept:
+		code = p.ChallengePlus4(move.Kind == MoveChallenge)
+	}
+	if code != protos.ErrorCode_success {
+		logger.Error(fmt.Sprintf("%d号玩家的机器人策略选择了不合法的行动：%s", p.location, code))
+		p.timeoutDraw()
+	}
+}
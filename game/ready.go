@@ -0,0 +1,113 @@
+package game
+
+import (
+	"fmt"
+	"github.com/CuteReimu/uno-server/protos"
+)
+
+// wait 座位没有坐满，回到等待开局的状态，所有人都要重新准备
+func (game *Game) wait() {
+	game.stopTurnTimer()
+	game.setPhase(PhaseWaiting)
+	for _, p := range game.Players {
+		if player, ok := p.(*HumanPlayer); ok {
+			player.ready = false
+		}
+	}
+	game.notifySeats()
+	game.notifyReadyState()
+}
+
+// host 房主，是最早入座的玩家。房主离开后由下一个玩家接替
+func (game *Game) host() *HumanPlayer {
+	for _, p := range game.Players {
+		if player, ok := p.(*HumanPlayer); ok {
+			return player
+		}
+	}
+	return nil
+}
+
+// allReady 除了except以外的玩家是否都准备好了
+func (game *Game) allReady(except *HumanPlayer) bool {
+	for _, p := range game.Players {
+		if player, ok := p.(*HumanPlayer); ok && player != except && !player.ready {
+			return false
+		}
+	}
+	return true
+}
+
+// setReady 玩家准备或者取消准备，座位坐满并且所有人都准备好后开局
+func (game *Game) setReady(player *HumanPlayer, ready bool) protos.ErrorCode {
+	if game.phase != PhaseWaiting {
+		return protos.ErrorCode_not_waiting
+	}
+	player.ready = ready
+	game.notifyReadyState()
+	if game.IsFull() && game.allReady(nil) {
+		logger.Info("所有玩家都准备好了")
+		game.start()
+	}
+	return protos.ErrorCode_success
+}
+
+// startEarly 房主提前开局，其他玩家都要准备好，空着的座位由机器人补上
+func (game *Game) startEarly(player *HumanPlayer) protos.ErrorCode {
+	if game.phase != PhaseWaiting {
+		return protos.ErrorCode_not_waiting
+	}
+	if game.host() != player {
+		return protos.ErrorCode_not_host
+	}
+	if !game.allReady(player) {
+		return protos.ErrorCode_not_all_ready
+	}
+	logger.Info(fmt.Sprintf("房主提前开局，由%d个机器人补上空位", game.TotalPlayerCount-len(game.Players)))
+	for !game.IsFull() {
+		game.Players = append(game.Players, &RobotPlayer{basePlayer: basePlayer{game: game, location: len(game.Players)}, strategy: defaultStrategy()})
+		game.RobotCount++
+	}
+	game.start()
+	return protos.ErrorCode_success
+}
+
+// voteRestart 玩家投票重开第generation局，超过一半的玩家投票后马上开始新的一局。generation为0表示当前这一局
+func (game *Game) voteRestart(player *HumanPlayer, generation int) protos.ErrorCode {
+	if game.phase == PhaseWaiting {
+		return protos.ErrorCode_game_not_started
+	}
+	if game.phase == PhaseDealing || generation != 0 && generation != game.generation {
+		return protos.ErrorCode_stale_request
+	}
+	player.restartVote = true
+	votes, humans := 0, 0
+	for _, p := range game.Players {
+		if human, ok := p.(*HumanPlayer); ok {
+			humans++
+			if human.restartVote {
+				votes++
+			}
+		}
+	}
+	needed := humans/2 + 1
+	logger.Info(fmt.Sprintf("%d号玩家投票重开，%d/%d", player.Location(), votes, needed))
+	for _, p := range game.audience() {
+		p.NotifyRestartVote(player.Location(), votes, needed)
+	}
+	if votes >= needed {
+		logger.Info("投票通过，重新开始")
+		game.start()
+	}
+	return protos.ErrorCode_success
+}
+
+// notifyReadyState 等待开局时，把准备状态发给所有玩家和观战者
+func (game *Game) notifyReadyState() {
+	if game.phase != PhaseWaiting {
+		return
+	}
+	for _, player := range game.audience() {
+		player.NotifyReadyState()
+	}
+}
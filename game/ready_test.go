@@ -0,0 +1,214 @@
+package game
+
+import (
+	"github.com/CuteReimu/uno-server/protos"
+	"testing"
+)
+
+func TestSetReady(t *testing.T) {
+	type step struct {
+		seat  int // 第几个入座的玩家
+		ready bool
+	}
+	tests := []struct {
+		name   string
+		total  int
+		humans int
+		steps  []step
+		want   Phase
+	}{
+		{"没有坐满", 3, 2, []step{{0, true}, {1, true}}, PhaseWaiting},
+		{"有人没准备", 2, 2, []step{{0, true}}, PhaseWaiting},
+		{"都准备好了", 2, 2, []step{{0, true}, {1, true}}, PhasePlaying},
+		{"取消准备", 3, 3, []step{{0, true}, {1, true}, {0, false}, {2, true}}, PhaseWaiting},
+		{"重新准备", 2, 2, []step{{0, true}, {0, false}, {1, true}, {0, true}}, PhasePlaying},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, _, sessions := newWaitingGame(t, tt.total, 0, tt.humans, Rules{})
+			for _, step := range tt.steps {
+				if code := g.setReady(g.humanMap[sessions[step.seat].ID()], step.ready); code != protos.ErrorCode_success {
+					t.Fatalf("准备失败：%s", code)
+				}
+			}
+			if g.Phase() != tt.want {
+				t.Fatalf("现在是%s，应该是%s", g.Phase(), tt.want)
+			}
+			if tt.want == PhasePlaying {
+				if code := g.setReady(g.humanMap[sessions[0].ID()], false); code != protos.ErrorCode_not_waiting {
+					t.Errorf("开局后不能取消准备，结果是%s", code)
+				}
+			}
+		})
+	}
+}
+
+func TestStartEarly(t *testing.T) {
+	tests := []struct {
+		name   string
+		total  int
+		robots int
+		humans int
+		ready  []int // 准备好的玩家，按入座的顺序，0号是房主
+		seat   int   // 要求提前开局的玩家
+		want   protos.ErrorCode
+	}{
+		{"不是房主", 4, 0, 2, []int{0, 1}, 1, protos.ErrorCode_not_host},
+		{"有人没准备", 4, 0, 3, []int{0, 1}, 0, protos.ErrorCode_not_all_ready},
+		{"房主自己不用准备", 4, 0, 2, []int{1}, 0, protos.ErrorCode_success},
+		{"只有房主", 3, 0, 1, nil, 0, protos.ErrorCode_success},
+		{"已经有机器人", 5, 1, 2, []int{1}, 0, protos.ErrorCode_success},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, _, sessions := newWaitingGame(t, tt.total, tt.robots, tt.humans, Rules{})
+			for _, seat := range tt.ready {
+				g.setReady(g.humanMap[sessions[seat].ID()], true)
+			}
+			code := g.startEarly(g.humanMap[sessions[tt.seat].ID()])
+			if code != tt.want {
+				t.Fatalf("提前开局的结果是%s，应该是%s", code, tt.want)
+			}
+			if code != protos.ErrorCode_success {
+				if g.Phase() != PhaseWaiting || len(g.Players) != tt.robots+tt.humans || g.RobotCount != tt.robots {
+					t.Error("提前开局失败时不应该补上机器人")
+				}
+				return
+			}
+			if g.Phase() != PhasePlaying {
+				t.Fatalf("提前开局后现在是%s", g.Phase())
+			}
+			robots := 0
+			for _, p := range g.Players {
+				if _, ok := p.(*RobotPlayer); ok {
+					robots++
+				}
+			}
+			if wantRobots := tt.total - tt.humans; len(g.Players) != tt.total || robots != wantRobots || g.RobotCount != wantRobots {
+				t.Errorf("空位应该由机器人补上，现在有%d个座位，%d个机器人，RobotCount是%d", len(g.Players), robots, g.RobotCount)
+			}
+			if code := g.startEarly(g.humanMap[sessions[0].ID()]); code != protos.ErrorCode_not_waiting {
+				t.Errorf("开局后不能再提前开局，结果是%s", code)
+			}
+		})
+	}
+}
+
+func TestVoteRestart(t *testing.T) {
+	tests := []struct {
+		name    string
+		humans  int
+		votes   []int // 依次投票的玩家，按入座的顺序
+		stale   bool  // 投票重开的是上一局
+		want    protos.ErrorCode
+		restart bool
+	}{
+		{"一个人", 1, []int{0}, false, protos.ErrorCode_success, true},
+		{"两个人只有一票", 2, []int{0}, false, protos.ErrorCode_success, false},
+		{"两个人都投票", 2, []int{0, 1}, false, protos.ErrorCode_success, true},
+		{"重复投票只算一票", 2, []int{0, 0}, false, protos.ErrorCode_success, false},
+		{"三个人两票", 3, []int{2, 0}, false, protos.ErrorCode_success, true},
+		{"四个人两票", 4, []int{0, 1}, false, protos.ErrorCode_success, false},
+		{"四个人三票", 4, []int{0, 1, 3}, false, protos.ErrorCode_success, true},
+		{"上一局", 1, []int{0}, true, protos.ErrorCode_stale_request, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, _, sessions := newTestGame(t, 1, tt.humans, Rules{})
+			generation := 0
+			if tt.stale {
+				generation = g.Generation()
+				g.start()
+			}
+			before := g.Generation()
+			var code protos.ErrorCode
+			for _, seat := range tt.votes {
+				code = g.voteRestart(g.humanMap[sessions[seat].ID()], generation)
+			}
+			if code != tt.want {
+				t.Fatalf("投票的结果是%s，应该是%s", code, tt.want)
+			}
+			if restarted := g.Generation() != before; restarted != tt.restart {
+				t.Fatalf("是否重开：%v，应该是%v", restarted, tt.restart)
+			}
+			if g.Phase() != PhasePlaying {
+				t.Fatalf("现在是%s", g.Phase())
+			}
+			if tt.restart {
+				for _, p := range g.Players {
+					if player, ok := p.(*HumanPlayer); ok && player.restartVote {
+						t.Error("重开后投票应该清空")
+					}
+				}
+			}
+		})
+	}
+	t.Run("还没开局", func(t *testing.T) {
+		g, _, _, sessions := newWaitingGame(t, 2, 0, 1, Rules{})
+		if code := g.voteRestart(g.humanMap[sessions[0].ID()], 0); code != protos.ErrorCode_game_not_started {
+			t.Errorf("还没开局时不能投票重开，结果是%s", code)
+		}
+	})
+}
+
+func TestJoinDuringRoundOver(t *testing.T) {
+	tests := []struct {
+		name   string
+		change bool // 一局结束后有人离开，又有新玩家入座
+		want   Phase
+	}{
+		{"没人换", false, PhasePlaying},
+		{"有人换", true, PhaseWaiting},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, q, c, sessions := newTestGame(t, 1, 2, Rules{})
+			winner := g.Players[g.WhoseTurn].base()
+			card := newNumberCard(1000, uint32(ColorRed), 5)
+			winner.cards = map[uint32]ICard{card.Id(): card}
+			g.LastCard, g.WantColor = newNumberCard(1001, uint32(ColorRed), 6), ColorRed
+			if code := winner.PlayCard(card.Id()); code != protos.ErrorCode_success {
+				t.Fatalf("出牌失败：%s", code)
+			}
+			if g.Phase() != PhaseRoundOver {
+				t.Fatalf("这一局应该结束了，现在是%s", g.Phase())
+			}
+			generation := g.Generation()
+			if tt.change {
+				g.Leave(sessions[0])
+				newcomer := &testSession{id: 3}
+				if !g.Join(newcomer, Identity{Name: "newcomer"}) {
+					t.Fatal("一局结束后空出来的座位应该可以入座")
+				}
+				if g.Phase() != PhaseWaiting {
+					t.Fatalf("新玩家入座后应该回到等待开局，现在是%s", g.Phase())
+				}
+				if g.humanMap[sessions[1].ID()].ready {
+					t.Fatal("回到等待开局后所有人都要重新准备")
+				}
+			}
+			// 10秒后自动开始下一局
+			for c.advance() {
+				q.drain()
+				if g.Generation() != generation {
+					break
+				}
+			}
+			if g.Phase() != tt.want {
+				t.Fatalf("现在是%s，应该是%s", g.Phase(), tt.want)
+			}
+			if !tt.change {
+				return
+			}
+			for _, p := range g.Players {
+				if player, ok := p.(*HumanPlayer); ok {
+					g.Handle(player.Session, &protos.ReadyTos{Ready: true})
+				}
+			}
+			q.drain()
+			if g.Phase() != PhasePlaying {
+				t.Errorf("所有人重新准备好后应该开局，现在是%s", g.Phase())
+			}
+		})
+	}
+}
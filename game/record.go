@@ -0,0 +1,232 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/CuteReimu/uno-server/config"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RecordVersion 游戏记录文件格式的版本，格式不兼容地修改时加一
+const RecordVersion = 1
+
+// 游戏记录中的事件类型
+const (
+	EventDeal      = "deal"       // 发牌，Player的初始手牌
+	EventFlip      = "flip"       // 翻开第一张牌
+	EventDraw      = "draw"       // Player摸牌
+	EventDiscard   = "discard"    // Player出牌，黑牌带WantColor，7-0规则下的7带Target
+	EventTurn      = "turn"       // 轮到Player，Dir是当前的方向
+	EventPass      = "pass"       // Player摸牌后不出
+	EventUnoCall   = "uno_call"   // Player喊UNO
+	EventUnoCatch  = "uno_catch"  // Player抓到Target忘记喊UNO
+	EventChallenge = "challenge"  // Player质疑Target打出的+4，Success表示是否质疑成功
+	EventSwap      = "swap"       // Player和Target交换手牌
+	EventRotate    = "rotate"     // 所有人把手牌传给下一个玩家
+	EventRoundOver = "round_over" // Player打完了手牌，得到Count分
+	EventAbort     = "abort"      // 房间解散，这一局没有打完
+	EventChat      = "chat"       // Player发了聊天消息Text
+	EventEmote     = "emote"      // Player发了快捷表情，Text是表情的名字
+)
+
+// RecordHeader 游戏记录文件的第一行
+type RecordHeader struct {
+	Version     int        `json:"version"`
+	Id          string     `json:"id"`
+	Seed        int64      `json:"seed"`
+	Round       int        `json:"round"`                // 比赛中的第几局
+	PlayerCount int        `json:"player_count"`         // 人数
+	Players     []string   `json:"players"`              // 每个座位是玩家还是机器人：玩家为human，机器人为策略的名字
+	Identities  []Identity `json:"identities,omitempty"` // 每个座位的玩家的身份
+	Rules       Rules      `json:"rules"`
+	StartTime   time.Time  `json:"start_time"`
+}
+
+// RecordCard 游戏记录中的一张牌
+type RecordCard struct {
+	Id    uint32 `json:"id"`
+	Color Color  `json:"color"`
+	Num   uint32 `json:"num"`
+}
+
+// Event 游戏记录中的一个事件，之后每行一个。座位号都是绝对位置
+type Event struct {
+	Time      int64        `json:"t"` // 距离这一局开始的毫秒数
+	Type      string       `json:"type"`
+	Player    int          `json:"player"`
+	Target    int          `json:"target,omitempty"`
+	Cards     []RecordCard `json:"cards,omitempty"`
+	WantColor Color        `json:"want_color,omitempty"`
+	Dir       bool         `json:"dir,omitempty"`
+	Count     int          `json:"count,omitempty"`
+	Success   bool         `json:"success,omitempty"`
+	Text      string       `json:"text,omitempty"`
+}
+
+// Record 一局游戏的完整记录
+type Record struct {
+	RecordHeader
+	Events []Event
+}
+
+func (c RecordCard) String() string {
+	switch c.Num {
+	case 10:
+		return c.Color.String() + "跳过"
+	case 11:
+		return c.Color.String() + "转向"
+	case 12:
+		return c.Color.String() + "+2"
+	case 13:
+		return "黑色变色"
+	case 14:
+		return "黑色+4"
+	}
+	return c.Color.String() + strconv.Itoa(int(c.Num))
+}
+
+func recordCards(cards ...ICard) []RecordCard {
+	result := make([]RecordCard, 0, len(cards))
+	for _, card := range cards {
+		result = append(result, RecordCard{Id: card.Id(), Color: card.Color(), Num: card.Number()})
+	}
+	return result
+}
+
+// newRecord 开始记录新的一局，此时已经发完了牌，还没有翻开第一张牌
+func (game *Game) newRecord() {
+	if !game.Recording {
+		game.record = nil
+		return
+	}
+	start := game.Clock.Now()
+	game.record = &Record{RecordHeader: RecordHeader{
+		Version:     RecordVersion,
+		Id:          fmt.Sprintf("%s-%d", start.Format("20060102-150405.000"), game.Seed),
+		Seed:        game.Seed,
+		Round:       game.Match.Round,
+		PlayerCount: game.TotalPlayerCount,
+		Rules:       game.Rules,
+		StartTime:   start,
+	}}
+	for _, player := range game.Players {
+		name := "human"
+		if robot, ok := player.(*RobotPlayer); ok {
+			name = robot.strategy.Name()
+		}
+		game.record.Players = append(game.record.Players, name)
+		game.record.Identities = append(game.record.Identities, player.Identity())
+		var cards []ICard
+		player.ForeachCards(func(card ICard) bool {
+			cards = append(cards, card)
+			return true
+		})
+		slices.SortFunc(cards, compareCardId) // 手牌存在map里，排个序让同一个种子的记录完全一样
+		game.recordEvent(Event{Type: EventDeal, Player: player.Location(), Cards: recordCards(cards...)})
+	}
+}
+
+// recordEvent 往这一局的记录中加一个事件，不记录的时候什么也不做
+func (game *Game) recordEvent(e Event) {
+	if game.record == nil {
+		return
+	}
+	e.Time = game.Clock.Now().Sub(game.record.StartTime).Milliseconds()
+	game.record.Events = append(game.record.Events, e)
+}
+
+// abortRecord 这一局没有打完就结束了，也要保存记录
+func (game *Game) abortRecord() {
+	if game.record != nil {
+		game.recordEvent(Event{Type: EventAbort})
+		game.saveRecord()
+	}
+}
+
+// saveRecord 这一局结束了，把记录写到文件中
+func (game *Game) saveRecord() {
+	record := game.record
+	if record == nil {
+		return
+	}
+	game.record = nil
+	go func() {
+		if err := record.Save(config.GlobalConfig.GetString("replay.dir")); err != nil {
+			logger.Error("保存游戏记录失败", "error", err)
+		}
+	}()
+}
+
+// Save 把记录写到dir目录下的 <Id>.jsonl 文件中，第一行是 RecordHeader ，之后每行一个 Event
+func (r *Record) Save(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(dir, r.Id+".jsonl"))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	w := bufio.NewWriter(f)
+	encoder := json.NewEncoder(w)
+	if err = encoder.Encode(r.RecordHeader); err != nil {
+		return err
+	}
+	for _, e := range r.Events {
+		if err = encoder.Encode(e); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// LoadRecord 读取 Record.Save 保存的记录文件
+func LoadRecord(path string) (*Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	decoder := json.NewDecoder(bufio.NewReader(f))
+	r := new(Record)
+	if err = decoder.Decode(&r.RecordHeader); err != nil {
+		return nil, err
+	}
+	if r.Version != RecordVersion {
+		return nil, fmt.Errorf("不支持的游戏记录版本：%d", r.Version)
+	}
+	for decoder.More() {
+		var e Event
+		if err = decoder.Decode(&e); err != nil {
+			return nil, err
+		}
+		r.Events = append(r.Events, e)
+	}
+	return r, nil
+}
+
+// RecordPath 名为id的记录文件的路径，id不合法则返回false
+func RecordPath(id string) (string, bool) {
+	if id == "" || filepath.Base(id) != id {
+		return "", false
+	}
+	return filepath.Join(config.GlobalConfig.GetString("replay.dir"), id+".jsonl"), true
+}
+
+// RecordIds 所有保存了的记录，按时间从早到晚排列
+func RecordIds() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(config.GlobalConfig.GetString("replay.dir"), "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(paths))
+	for _, path := range paths {
+		ids = append(ids, filepath.Base(path[:len(path)-len(".jsonl")]))
+	}
+	return ids, nil
+}
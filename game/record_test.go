@@ -0,0 +1,86 @@
+package game
+
+import (
+	"github.com/CuteReimu/uno-server/config"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+// playRecordedGame 用固定的种子进行一局机器人对局，返回这一局的记录
+func playRecordedGame(t *testing.T, seed int64, rules Rules) *Record {
+	var robots []Strategy
+	for _, name := range []string{"easy", "normal", "hard"} {
+		strategy, err := NewStrategy(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		robots = append(robots, strategy)
+	}
+	var record *Record
+	q := new(testQueue)
+	c := &testClock{now: time.Unix(0, 0)}
+	g := NewGame(q, len(robots), robots, rules)
+	g.Clock = c
+	g.InitialSeed = seed
+	g.Recording = true
+	q.check = func() {
+		if g.record != nil {
+			record = g.record
+		}
+	}
+	g.Start()
+	q.drain()
+	for g.IsPlaying() && c.advance() {
+		q.drain()
+	}
+	if record == nil || record.Events[len(record.Events)-1].Type != EventRoundOver {
+		t.Fatalf("种子%d：这一局没有完整的记录", seed)
+	}
+	return record
+}
+
+func TestSeedReproducible(t *testing.T) {
+	for _, rules := range []Rules{{}, {SevenZero: true, JumpIn: true, StackPlus2: true, ChallengePlus4: true}} {
+		for seed := int64(1); seed <= 5; seed++ {
+			a, b := playRecordedGame(t, seed, rules), playRecordedGame(t, seed, rules)
+			if a.Seed != seed || !reflect.DeepEqual(a.Events, b.Events) {
+				t.Errorf("房规%+v，种子%d：同样的种子应该打出同样的一局", rules, seed)
+			}
+		}
+	}
+	if reflect.DeepEqual(playRecordedGame(t, 1, Rules{}).Events, playRecordedGame(t, 2, Rules{}).Events) {
+		t.Error("不同的种子不应该打出同样的一局")
+	}
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	// 对局结束时还在后台保存原来的记录，这里改一份副本，换一个文件名
+	record := *playRecordedGame(t, 42, Rules{SevenZero: true})
+	record.Id += "-copy"
+	record.Events = append(slices.Clone(record.Events), Event{Type: EventChat, Player: 1, Text: "你好，\"UNO\"\n"})
+	dir := config.GlobalConfig.GetString("replay.dir")
+	if err := record.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	path, ok := RecordPath(record.Id)
+	if !ok || path != filepath.Join(dir, record.Id+".jsonl") {
+		t.Fatalf("记录文件的路径不对：%s", path)
+	}
+	loaded, err := LoadRecord(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.StartTime = record.StartTime.UTC()
+	loaded.StartTime = loaded.StartTime.UTC()
+	if !reflect.DeepEqual(&record, loaded) {
+		t.Errorf("读取的记录和保存的不一样：\n保存：%+v\n读取：%+v", record.RecordHeader, loaded.RecordHeader)
+	}
+	for _, id := range []string{"", "../" + record.Id, "a/b"} {
+		if _, ok := RecordPath(id); ok {
+			t.Errorf("RecordPath(%q)应该不合法", id)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package game
+
+import (
+	"github.com/CuteReimu/uno-server/config"
+	"github.com/CuteReimu/uno-server/protos"
+)
+
+// Rules 房规，每个房间可以不同
+type Rules struct {
+	JumpIn            bool `mapstructure:"jump_in" json:"jump_in"`                         // 抢牌：手里有和最后打出的牌完全相同的牌时，不在自己的回合也可以打出，然后从他开始继续
+	SevenZero         bool `mapstructure:"seven_zero" json:"seven_zero"`                   // 7-0：打出7时和指定的玩家交换手牌，打出0时所有人把手牌传给下一个玩家
+	DrawUntilPlayable bool `mapstructure:"draw_until_playable" json:"draw_until_playable"` // 摸牌时一直摸到能打出的牌为止
+	ForcePlay         bool `mapstructure:"force_play" json:"force_play"`                   // 摸到的牌能打出时必须打出，不能选择不出
+	StackPlus2        bool `mapstructure:"stack_plus2" json:"stack_plus2"`                 // 被+2时可以再打出+2，让下家累积摸牌
+	StackPlus4        bool `mapstructure:"stack_plus4" json:"stack_plus4"`                 // 被+2或+4时可以再打出+4，让下家累积摸牌
+	ChallengePlus4    bool `mapstructure:"challenge_plus4" json:"challenge_plus4"`         // +4质疑（官方规则）：+4任何时候都可以打出，但下家可以质疑
+	TargetScore       int  `mapstructure:"target_score" json:"target_score"`               // 比赛的目标分数，有人的总分达到后比赛结束，为0表示不计分
+}
+
+// DefaultRules 配置文件中的默认房规
+func DefaultRules() Rules {
+	var rules Rules
+	if err := config.GlobalConfig.UnmarshalKey("rules", &rules); err != nil {
+		logger.Error("读取房规失败", "error", err)
+	}
+	return rules
+}
+
+func RulesFromProto(msg *protos.Rules) Rules {
+	return Rules{
+		JumpIn:            msg.GetJumpIn(),
+		SevenZero:         msg.GetSevenZero(),
+		DrawUntilPlayable: msg.GetDrawUntilPlayable(),
+		ForcePlay:         msg.GetForcePlay(),
+		StackPlus2:        msg.GetStackPlus2(),
+		StackPlus4:        msg.GetStackPlus4(),
+		ChallengePlus4:    msg.GetChallengePlus4(),
+		TargetScore:       int(msg.GetTargetScore()),
+	}
+}
+
+func (r *Rules) ToProto() *protos.Rules {
+	return &protos.Rules{
+		JumpIn:            r.JumpIn,
+		SevenZero:         r.SevenZero,
+		DrawUntilPlayable: r.DrawUntilPlayable,
+		ForcePlay:         r.ForcePlay,
+		StackPlus2:        r.StackPlus2,
+		StackPlus4:        r.StackPlus4,
+		ChallengePlus4:    r.ChallengePlus4,
+		TargetScore:       uint32(r.TargetScore),
+	}
+}
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"fmt"
+	"github.com/CuteReimu/uno-server/config"
+	"github.com/CuteReimu/uno-server/protos"
+	"github.com/davyxu/cellnet"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Spectator 观战者。以0号座位的视角接收公开的消息，所以消息中的玩家ID就是座位号。
+// 他不在 Game.Players 中，只会收到 Game.audience 发出的通知，可能泄露手牌的方法都要覆盖掉
+type Spectator struct {
+	HumanPlayer
+	godViewDelay time.Duration // 上帝视角的延迟，为0表示没有开启上帝视角
+}
+
+// Location 不是任何一个座位，这样摸牌时不会收到摸到的牌
+func (s *Spectator) Location() int {
+	return -1
+}
+
+func (s *Spectator) NotifyDiscardCard(location int, card ICard, args ...uint32) {
+	s.Send(s.discardCardToc(location, card, args...))
+}
+
+func (s *Spectator) NotifyTurn(location int, dir bool) {
+	s.HumanPlayer.NotifyTurn(location, dir)
+	if s.godViewDelay > 0 {
+		s.scheduleGodView()
+	}
+}
+
+// NotifyGameState 不能带上0号座位的手牌和他正在做的决定
+func (s *Spectator) NotifyGameState() {
+	msg := s.gameState()
+	msg.HandCard = nil
+	msg.ChallengePending = false
+	msg.DrawnCard = nil
+	s.Send(msg)
+}
+
+func (s *Spectator) NotifySeats() {
+	s.Send(s.seats(0))
+}
+
+func (s *Spectator) NotifyReadyState() {
+	s.Send(s.readyState(0))
+}
+
+// scheduleGodView 记下现在所有人的手牌，延迟一段时间后发给他
+func (s *Spectator) scheduleGodView() {
+	msg := &protos.GodViewToc{Time: s.game.Clock.Now().UnixMilli()}
+	for location, player := range s.game.Players {
+		cards := make(map[uint32]ICard)
+		player.ForeachCards(func(card ICard) bool {
+			cards[card.Id()] = card
+			return true
+		})
+		hand := &protos.GodViewHand{PlayerId: uint32(location), Player: identityToProto(player.Identity())}
+		for _, cardId := range slices.Sorted(maps.Keys(cards)) {
+			hand.Cards = append(hand.Cards, cardToProto(cards[cardId]))
+		}
+		msg.Hands = append(msg.Hands, hand)
+	}
+	generation := s.game.generation
+	s.game.Clock.AfterFunc(s.godViewDelay, func() {
+		s.game.Post(func() {
+			if s.game.generation == generation && slices.Contains(s.game.spectators, s) {
+				s.Send(msg)
+			}
+		})
+	})
+}
+
+// GodViewDelay 上帝视角的延迟，为0表示不允许上帝视角
+func GodViewDelay() time.Duration {
+	if !config.GlobalConfig.GetBool("spectator.god_view") {
+		return 0
+	}
+	return time.Duration(config.GlobalConfig.GetInt("spectator.god_view_delay")) * time.Second
+}
+
+// Spectate 观战，godView表示是否开启上帝视角。开始观战后把当前局面发给他
+func (game *Game) Spectate(session cellnet.Session, identity Identity, godView bool) {
+	s := &Spectator{HumanPlayer: HumanPlayer{basePlayer: basePlayer{game: game, cards: make(map[uint32]ICard)}, Session: session, identity: identity}}
+	if godView {
+		s.godViewDelay = GodViewDelay()
+	}
+	game.spectators = append(game.spectators, s)
+	logger.Info(fmt.Sprintf("开始观战，现在有%d人观战", len(game.spectators)), "sessionId", session.ID())
+	s.Send(s.initToc())
+	s.NotifyGameState()
+	s.NotifySeats()
+	if game.phase == PhaseWaiting {
+		s.NotifyReadyState()
+	}
+}
+
+// SpectatorCount 观战人数
+func (game *Game) SpectatorCount() int {
+	return len(game.spectators)
+}
+
+// stopSpectating 观战者离开，返回session是不是观战者
+func (game *Game) stopSpectating(session cellnet.Session) bool {
+	index := slices.IndexFunc(game.spectators, func(s *Spectator) bool { return s.Session.ID() == session.ID() })
+	if index < 0 {
+		return false
+	}
+	game.spectators = slices.Delete(game.spectators, index, index+1)
+	logger.Info(fmt.Sprintf("停止观战，现在有%d人观战", len(game.spectators)), "sessionId", session.ID())
+	return true
+}
+
+// spectator session对应的观战者，不是观战者则返回nil
+func (game *Game) spectator(session cellnet.Session) *Spectator {
+	for _, s := range game.spectators {
+		if s.Session.ID() == session.ID() {
+			return s
+		}
+	}
+	return nil
+}
+
+// audience 所有要接收公开消息的人，包括所有玩家和观战者
+func (game *Game) audience() []IPlayer {
+	audience := slices.Clip(game.Players)
+	for _, s := range game.spectators {
+		audience = append(audience, s)
+	}
+	return audience
+}
@@ -0,0 +1,150 @@
+package game
+
+import (
+	"github.com/CuteReimu/uno-server/protos"
+	"testing"
+	"time"
+)
+
+// newSpectatedGame 两个机器人和一个超时后由机器人代打的玩家，有一个普通观战者和一个上帝视角的观战者。
+// 观战者在发牌之前加入，返回时刚投票重开发完牌
+func newSpectatedGame(t *testing.T) (g *Game, q *testQueue, c *testClock, plain, god *testSession) {
+	setConfig(t, "spectator.god_view", true)
+	setConfig(t, "spectator.god_view_delay", 5)
+	setConfig(t, "turn.timeout", 1)
+	setConfig(t, "turn.time_bank", 0)
+	setConfig(t, "turn.timeout_action", "robot")
+	g, q, c, sessions := newTestGame(t, 2, 1, Rules{ChallengePlus4: true, DrawUntilPlayable: true, StackPlus2: true})
+	plain, god = &testSession{id: 100}, &testSession{id: 101}
+	g.Spectate(plain, Identity{Name: "plain"}, false)
+	g.Spectate(god, Identity{Name: "god"}, true)
+	g.Handle(sessions[0], &protos.RestartGameTos{})
+	q.drain()
+	if g.Phase() != PhasePlaying {
+		t.Fatalf("投票重开后应该开局，现在是%s", g.Phase())
+	}
+	return
+}
+
+// checkSpectatorMessages 检查观战者收到的消息里没有任何人的手牌，上帝视角的手牌要等到延迟之后才能收到。返回收到了几次上帝视角
+func checkSpectatorMessages(t *testing.T, c *testClock, session *testSession, godView bool) (godViews int) {
+	t.Helper()
+	for _, msg := range session.sent {
+		switch msg := msg.(type) {
+		case *protos.DrawCardToc:
+			t.Fatalf("观战者收到了摸到的牌：%v", msg.Card)
+		case *protos.DrawnCardToc:
+			t.Fatalf("观战者收到了摸到的能打出的牌：%v", msg.Card)
+		case *protos.RevealHandToc:
+			t.Fatalf("观战者收到了被质疑的玩家的手牌：%v", msg.Cards)
+		case *protos.GameStateToc:
+			if len(msg.HandCard) > 0 || msg.DrawnCard != nil || msg.ChallengePending {
+				t.Fatalf("观战者收到的局面里有0号座位的手牌或者决定：%v", msg)
+			}
+		case *protos.GodViewToc:
+			if !godView {
+				t.Fatal("没有开启上帝视角的观战者收到了所有人的手牌")
+			}
+			if recorded := time.UnixMilli(msg.Time); c.Now().Before(recorded.Add(5 * time.Second)) {
+				t.Fatalf("%v记录的手牌在%v就发出来了", recorded, c.Now())
+			}
+			for _, hand := range msg.Hands {
+				if hand.Player == nil || hand.Player.Name == "" {
+					t.Fatalf("上帝视角中%d号座位没有玩家的身份", hand.PlayerId)
+				}
+			}
+			godViews++
+		}
+	}
+	session.sent = nil
+	return godViews
+}
+
+func TestSpectatorSeesNoHands(t *testing.T) {
+	g, q, c, plain, god := newSpectatedGame(t)
+	godViews := 0
+	check := func() {
+		checkSpectatorMessages(t, c, plain, false)
+		godViews += checkSpectatorMessages(t, c, god, true)
+	}
+	q.check = check
+	check()
+	for step := 0; g.Phase() == PhasePlaying; step++ {
+		if step > 10000 {
+			t.Fatal("这一局太久了")
+		}
+		// 观战者中途请求完整的局面
+		if step%10 == 0 {
+			g.Handle(plain, &protos.RequestStateTos{})
+			g.Handle(god, &protos.RequestStateTos{})
+			check()
+		}
+		if !c.advance() {
+			t.Fatal("没有行动的玩家")
+		}
+		q.drain()
+	}
+	if g.Phase() != PhaseRoundOver {
+		t.Fatalf("这一局应该正常结束，现在是%s", g.Phase())
+	}
+	if godViews == 0 {
+		t.Error("上帝视角的观战者一直没有收到手牌")
+	}
+}
+
+func TestStaleGodView(t *testing.T) {
+	g, q, c, _, god := newSpectatedGame(t)
+	// 发牌后轮到某人时已经记下了手牌，过了1秒投票重开
+	c.now = c.now.Add(time.Second)
+	restart := c.Now()
+	var human *testSession
+	for _, p := range g.Players {
+		if player, ok := p.(*HumanPlayer); ok {
+			human = player.Session.(*testSession)
+		}
+	}
+	g.Handle(human, &protos.RestartGameTos{})
+	q.drain()
+	god.sent = nil
+	for c.Now().Before(restart.Add(6*time.Second)) && c.advance() {
+		q.drain()
+	}
+	fresh := 0
+	for _, msg := range god.sent {
+		if msg, ok := msg.(*protos.GodViewToc); ok {
+			if time.UnixMilli(msg.Time).Before(restart) {
+				t.Fatalf("重开之前记录的手牌不应该再发出来：%v", msg)
+			}
+			fresh++
+		}
+	}
+	if fresh == 0 {
+		t.Error("重开之后记录的手牌应该照常发出来")
+	}
+}
+
+// TestSpectatorGameState 观战者以0号座位的视角看局面，但不能看到0号正在做的决定
+func TestSpectatorGameState(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(g *Game)
+	}{
+		{"0号摸到了能打出的牌", func(g *Game) { g.drawnCard = newNumberCard(1000, uint32(ColorRed), 1) }},
+		{"0号要决定是否质疑+4", func(g *Game) { g.plus4Challenge = &plus4Challenge{player: 1} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, c, plain, god := newSpectatedGame(t)
+			g.WhoseTurn = 0
+			tt.prepare(g)
+			plain.sent, god.sent = nil, nil
+			g.Handle(plain, &protos.RequestStateTos{})
+			g.Handle(god, &protos.RequestStateTos{})
+			if len(plain.sent) == 0 || len(god.sent) == 0 {
+				t.Fatal("观战者应该收到局面")
+			}
+			checkSpectatorMessages(t, c, plain, false)
+			checkSpectatorMessages(t, c, god, true)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package game
+
+import (
+	"fmt"
+	"github.com/CuteReimu/uno-server/config"
+	"github.com/CuteReimu/uno-server/protos"
+	"math/rand"
+	"slices"
+)
+
+// Strategy 机器人的策略。每次需要机器人做决定时，传给它一个只读的局面，由它返回一步行动
+type Strategy interface {
+	// Name 策略的名字，也就是配置文件和创建房间时使用的名字
+	Name() string
+	// ChooseMove 根据局面选择一步行动
+	ChooseMove(view *View) Move
+}
+
+type MoveKind int
+
+const (
+	MovePlay      MoveKind = iota // 打出Card，参数是WantColor和Target
+	MoveDraw                      // 摸牌，如果在累积罚摸牌，则摸掉所有的罚摸牌
+	MovePass                      // 摸到能打出的牌之后不出
+	MoveChallenge                 // 质疑上家打出的+4
+	MoveAccept                    // 不质疑上家打出的+4
+)
+
+// Move 机器人的一步行动
+type Move struct {
+	Kind      MoveKind
+	Card      ICard
+	WantColor Color // 打出黑牌时选择的颜色
+	Target    int   // 7-0规则下打出7时选择交换手牌的玩家
+	CallUno   bool  // 出牌前是否喊UNO
+	CatchUno  bool  // 行动前是否抓忘记喊UNO的玩家
+}
+
+// View 机器人能看到的局面，只包含公开的信息和自己的手牌。座位号都是绝对位置
+type View struct {
+	Location      int     // 自己的座位号
+	Hand          []ICard // 自己的手牌，按ID排序
+	HandCounts    []int   // 每个座位的手牌数
+	LastCard      ICard
+	WantColor     Color
+	Dir           bool
+	WhoseTurn     int
+	PendingDraw   int     // 累积的罚摸牌数
+	DeckNum       int     // 牌堆剩余的牌数
+	DiscardPile   []ICard // 弃牌堆中的牌，按打出的顺序
+	Unseen        []ICard // 自己看不到的牌，也就是牌堆和其他玩家的手牌，按ID排序
+	Rules         Rules
+	DrawnCard     ICard      // 刚摸到的能打出的牌，正在决定是否打出，否则为nil
+	ChallengeFrom int        // 等待自己决定是否质疑+4时，打出+4的玩家，否则为-1
+	UnoVulnerable int        // 可以被抓的忘记喊UNO的玩家，没有则为-1
+	Random        *rand.Rand // 做决定时需要的随机数都要从这里取，这样同样的种子能复现同样的对局
+	game          *Game
+	player        *basePlayer
+}
+
+// view 为p生成一份当前的局面
+func (p *basePlayer) view() *View {
+	game := p.game
+	v := &View{
+		Location:      p.location,
+		HandCounts:    make([]int, len(game.Players)),
+		LastCard:      game.LastCard,
+		WantColor:     game.WantColor,
+		Dir:           game.Dir,
+		WhoseTurn:     game.WhoseTurn,
+		PendingDraw:   game.PendingDraw,
+		DeckNum:       len(game.Deck.cards),
+		DiscardPile:   slices.Clone(game.Deck.discardPile),
+		Rules:         game.Rules,
+		ChallengeFrom: -1,
+		UnoVulnerable: -1,
+		Random:        p.random,
+		game:          game,
+		player:        p,
+	}
+	for _, card := range p.cards {
+		v.Hand = append(v.Hand, card)
+	}
+	v.Unseen = slices.Clone(game.Deck.cards)
+	for location, player := range game.Players {
+		v.HandCounts[location] = player.CardCount()
+		if location != p.location {
+			player.ForeachCards(func(card ICard) bool {
+				v.Unseen = append(v.Unseen, card)
+				return true
+			})
+		}
+	}
+	slices.SortFunc(v.Hand, compareCardId)
+	slices.SortFunc(v.Unseen, compareCardId)
+	if game.WhoseTurn == p.location {
+		v.DrawnCard = game.drawnCard
+		if c := game.plus4Challenge; c != nil {
+			v.ChallengeFrom = c.player
+		}
+	}
+	if target := game.unoVulnerablePlayer(); target != nil && target.location != p.location && len(target.cards) == 1 {
+		v.UnoVulnerable = target.location
+	}
+	return v
+}
+
+func compareCardId(a, b ICard) int {
+	return int(a.Id()) - int(b.Id())
+}
+
+// CanPlay 按当前的房规和局面，自己能否带着这些参数打出card
+func (v *View) CanPlay(card ICard, wantColor Color, target int) bool {
+	return card.CanPlay(v.game, v.player, uint32(wantColor), uint32(target)) == protos.ErrorCode_success
+}
+
+// NextLocation 按当前的方向，从自己往后数n个座位的座位号
+func (v *View) NextLocation(n int) int {
+	if v.Dir {
+		n = -n
+	}
+	total := len(v.HandCounts)
+	return ((v.Location+n)%total + total) % total
+}
+
+// Playable 现在能打出的牌，黑牌按WantColor选色，7按Target选人
+func (v *View) Playable(wantColor Color, target int) []ICard {
+	if v.DrawnCard != nil {
+		if v.CanPlay(v.DrawnCard, wantColor, target) {
+			return []ICard{v.DrawnCard}
+		}
+		return nil
+	}
+	var cards []ICard
+	for _, card := range v.Hand {
+		if v.CanPlay(card, wantColor, target) {
+			cards = append(cards, card)
+		}
+	}
+	return cards
+}
+
+// ColorCounts 自己手里每种颜色的牌数，下标是Color
+func (v *View) ColorCounts() []int {
+	counts := make([]int, ColorBlue+1)
+	for _, card := range v.Hand {
+		counts[card.Color()]++
+	}
+	return counts
+}
+
+// FewestCardsOpponent 手牌最少的对手
+func (v *View) FewestCardsOpponent() int {
+	target := -1
+	for location, count := range v.HandCounts {
+		if location != v.Location && (target < 0 || count < v.HandCounts[target]) {
+			target = location
+		}
+	}
+	return target
+}
+
+var strategies = make(map[string]func() Strategy)
+
+// RegisterStrategy 注册一种机器人策略，每个机器人座位都会调用newStrategy创建自己的实例
+func RegisterStrategy(name string, newStrategy func() Strategy) {
+	strategies[name] = newStrategy
+}
+
+// NewStrategy 按名字创建机器人策略，名字为空则使用配置文件中的默认策略
+func NewStrategy(name string) (Strategy, error) {
+	if name == "" {
+		name = config.GlobalConfig.GetString("robot.strategy")
+	}
+	newStrategy, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("不存在的机器人策略：%s", name)
+	}
+	return newStrategy(), nil
+}
+
+// defaultStrategy 替离开或者断线的玩家托管时使用的策略
+func defaultStrategy() Strategy {
+	strategy, err := NewStrategy("")
+	if err != nil {
+		logger.Error("读取默认的机器人策略失败", "error", err)
+		return new(normalStrategy)
+	}
+	return strategy
+}
+
+// callUno 出牌后只剩一张时要喊UNO，但是会有forgetRate的概率忘记
+func callUno(v *View, forgetRate float64) bool {
+	return len(v.Hand) == 2 && v.Random.Float64() >= forgetRate
+}
+
+// giveUpMove 没有想出的牌时，摸牌，或者摸到的牌不出
+func giveUpMove(v *View, catch bool) Move {
+	if v.DrawnCard != nil && !v.Rules.ForcePlay {
+		return Move{Kind: MovePass, CatchUno: catch}
+	}
+	return Move{Kind: MoveDraw, CatchUno: catch}
+}
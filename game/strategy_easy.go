@@ -0,0 +1,32 @@
+package game
+
+func init() {
+	RegisterStrategy("easy", func() Strategy { return new(easyStrategy) })
+}
+
+// easyStrategy 简单的机器人：随便出一张能出的牌，随便选颜色，从不质疑+4，经常忘记喊UNO
+type easyStrategy struct{}
+
+func (s *easyStrategy) Name() string {
+	return "easy"
+}
+
+func (s *easyStrategy) ChooseMove(v *View) Move {
+	catch := v.UnoVulnerable >= 0 && v.Random.Float64() < 0.3
+	if v.ChallengeFrom >= 0 {
+		return Move{Kind: MoveAccept, CatchUno: catch}
+	}
+	wantColor := ColorRed + Color(v.Random.Intn(4))
+	target := v.FewestCardsOpponent()
+	if v.Rules.SevenZero {
+		if target = v.Random.Intn(len(v.HandCounts) - 1); target >= v.Location {
+			target++
+		}
+	}
+	playable := v.Playable(wantColor, target)
+	if len(playable) == 0 {
+		return giveUpMove(v, catch)
+	}
+	card := playable[v.Random.Intn(len(playable))]
+	return Move{Kind: MovePlay, Card: card, WantColor: wantColor, Target: target, CallUno: callUno(v, 0.3), CatchUno: catch}
+}
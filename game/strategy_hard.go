@@ -0,0 +1,76 @@
+package game
+
+import "math"
+
+func init() {
+	RegisterStrategy("hard", func() Strategy { return new(hardStrategy) })
+}
+
+// hardStrategy 困难的机器人：优先出分高的牌和手里多的颜色，留着黑牌，下家快出完时优先压制下家，从不忘记喊UNO
+type hardStrategy struct{}
+
+func (s *hardStrategy) Name() string {
+	return "hard"
+}
+
+func (s *hardStrategy) ChooseMove(v *View) Move {
+	catch := v.UnoVulnerable >= 0
+	if v.ChallengeFrom >= 0 {
+		// 粗略估计上家手里一张当时颜色的牌都没有的概率，也就是+4合规的概率。
+		// 不质疑要摸4张，质疑失败要摸6张，所以合规的概率低于2/3就值得质疑
+		legal := math.Pow(0.75, float64(v.HandCounts[v.ChallengeFrom]))
+		if legal < 2.0/3 {
+			return Move{Kind: MoveChallenge, CatchUno: catch}
+		}
+		return Move{Kind: MoveAccept, CatchUno: catch}
+	}
+	counts := v.ColorCounts()
+	scores := make([]int, ColorBlue+1)
+	for _, card := range v.Hand {
+		scores[card.Color()] += card.Score()
+	}
+	wantColor := ColorRed
+	for color := ColorGreen; color <= ColorBlue; color++ {
+		if counts[color] > counts[wantColor] || counts[color] == counts[wantColor] && scores[color] > scores[wantColor] {
+			wantColor = color
+		}
+	}
+	target := v.FewestCardsOpponent()
+	next := v.HandCounts[v.NextLocation(1)]
+	var best ICard
+	bestValue := math.MinInt
+	for _, card := range v.Playable(wantColor, target) {
+		value := card.Score() + 5*counts[card.Color()]
+		if card.Color() == ColorBlack && len(v.Hand) > 2 {
+			value -= 100 // 黑牌留到最后再出
+		}
+		if next <= 2 && s.attacks(v, card) {
+			value += 200 // 下家快出完了，能压制就压制
+		}
+		if v.Rules.SevenZero && card.Number() == 7 {
+			if v.HandCounts[target] < len(v.Hand)-1 {
+				value += 50
+			} else {
+				value -= 50
+			}
+		}
+		if value > bestValue {
+			best, bestValue = card, value
+		}
+	}
+	if best == nil {
+		return giveUpMove(v, catch)
+	}
+	return Move{Kind: MovePlay, Card: best, WantColor: wantColor, Target: target, CallUno: callUno(v, 0), CatchUno: catch}
+}
+
+// attacks 打出card能否让下家这回合出不了牌
+func (s *hardStrategy) attacks(v *View, card ICard) bool {
+	switch card.Number() {
+	case 10, 12, 14:
+		return true
+	case 11:
+		return len(v.HandCounts) == 2
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package game
+
+import (
+	"github.com/CuteReimu/uno-server/config"
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+func init() {
+	RegisterStrategy("mcts", func() Strategy {
+		return &mctsStrategy{
+			iterations: config.GlobalConfig.GetInt("robot.mcts.iterations"),
+			timeLimit:  time.Duration(config.GlobalConfig.GetInt("robot.mcts.time_limit")) * time.Millisecond,
+		}
+	})
+}
+
+const (
+	mctsExploration = 0.7 // UCB公式中探索项的系数
+	mctsMaxSteps    = 500 // 每次模拟最多走的步数，走完还没有人打完手牌，就算手牌最少的玩家赢
+)
+
+// mctsStrategy 信息集蒙特卡洛树搜索（ISMCTS）的机器人。每次搜索先把看不到的牌随机分给其他玩家，
+// 在这个可能的局面上沿着搜索树选择、扩展一步，然后随机模拟到有人打完手牌，所有的抽样共用同一棵搜索树。
+// 是否质疑+4、喊UNO和抓UNO的决定和hard策略相同
+type mctsStrategy struct {
+	hardStrategy
+	iterations int           // 每次做决定最多搜索的次数
+	timeLimit  time.Duration // 每次做决定最多搜索的时间，为0表示不限时。限时的话，同样的种子也不一定能复现同样的决定
+}
+
+func (s *mctsStrategy) Name() string {
+	return "mcts"
+}
+
+func (s *mctsStrategy) ChooseMove(v *View) Move {
+	if v.ChallengeFrom >= 0 {
+		return s.hardStrategy.ChooseMove(v)
+	}
+	catch := v.UnoVulnerable >= 0
+	move := s.search(v)
+	if move.kind != MovePlay {
+		return Move{Kind: move.kind, CatchUno: catch}
+	}
+	card := v.DrawnCard
+	if card == nil {
+		card = v.Hand[slices.IndexFunc(v.Hand, func(card ICard) bool {
+			return card.Color() == move.color && card.Number() == move.number
+		})]
+	}
+	return Move{Kind: MovePlay, Card: card, WantColor: move.wantColor, Target: v.FewestCardsOpponent(), CallUno: callUno(v, 0), CatchUno: catch}
+}
+
+// mctsNode 搜索树的节点，代表某个玩家走的一步
+type mctsNode struct {
+	move     simMove
+	player   int // 走这一步的玩家
+	parent   *mctsNode
+	children []*mctsNode
+	visits   int     // 经过这个节点的次数
+	avail    int     // 在父节点做选择时，这一步是合法行动的次数
+	wins     float64 // 经过这个节点后，player赢了的次数
+}
+
+// search 搜索出自己这一步最好的行动
+func (s *mctsStrategy) search(v *View) simMove {
+	// 搜索用的随机数很多，只从v.Random中取一个作为种子，这样搜索的次数不会影响之后的决定
+	random := rand.New(rand.NewSource(v.Random.Int63()))
+	if moves := determinize(v, random).legalMoves(); len(moves) == 1 {
+		return moves[0]
+	}
+	root := new(mctsNode)
+	deadline := time.Now().Add(s.timeLimit)
+	for i := 0; i == 0 || i < s.iterations; i++ {
+		if s.timeLimit > 0 && i%16 == 0 && time.Now().After(deadline) {
+			break
+		}
+		state := determinize(v, random)
+		node := root
+		for state.winner < 0 {
+			moves := state.legalMoves()
+			var untried []simMove
+			for _, move := range moves {
+				if !slices.ContainsFunc(node.children, func(child *mctsNode) bool { return child.move == move }) {
+					untried = append(untried, move)
+				}
+			}
+			if len(untried) > 0 {
+				child := &mctsNode{move: untried[random.Intn(len(untried))], player: state.turn, parent: node, avail: 1}
+				node.children = append(node.children, child)
+				state.apply(child.move)
+				node = child
+				break
+			}
+			node = node.selectChild(moves)
+			state.apply(node.move)
+		}
+		for step := 0; state.winner < 0 && step < mctsMaxSteps; step++ {
+			state.apply(state.randomMove())
+		}
+		winner := state.winner
+		if winner < 0 {
+			for location, hand := range state.hands {
+				if winner < 0 || len(hand) < len(state.hands[winner]) {
+					winner = location
+				}
+			}
+		}
+		for ; node != nil; node = node.parent {
+			node.visits++
+			if node.player == winner {
+				node.wins++
+			}
+		}
+	}
+	best := root.children[0]
+	for _, child := range root.children {
+		if child.visits > best.visits {
+			best = child
+		}
+	}
+	return best.move
+}
+
+// selectChild 在这一次抽样中合法的子节点里，按UCB选择一个
+func (n *mctsNode) selectChild(moves []simMove) *mctsNode {
+	var best *mctsNode
+	bestValue := math.Inf(-1)
+	for _, child := range n.children {
+		if !slices.Contains(moves, child.move) {
+			continue
+		}
+		child.avail++
+		value := child.wins/float64(child.visits) + mctsExploration*math.Sqrt(math.Log(float64(child.avail))/float64(child.visits))
+		if value > bestValue {
+			best, bestValue = child, value
+		}
+	}
+	return best
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// testView 自己坐在0号位并且轮到自己，其他人的手牌和牌堆从一副标准的牌中随机抽取
+func testView(hand []ICard, last ICard, want Color, counts []int, pending int, rules Rules) *View {
+	return &View{
+		Hand:          hand,
+		HandCounts:    counts,
+		LastCard:      last,
+		WantColor:     want,
+		PendingDraw:   pending,
+		Unseen:        StandardDeck.Cards(),
+		Rules:         rules,
+		ChallengeFrom: -1,
+		UnoVulnerable: -1,
+		Random:        rand.New(rand.NewSource(1)),
+	}
+}
+
+func TestSimLegalMoves(t *testing.T) {
+	red3, red5, blue5, blue7 := newNumberCard(1, uint32(ColorRed), 3), newNumberCard(2, uint32(ColorRed), 5), newNumberCard(3, uint32(ColorBlue), 5), newNumberCard(4, uint32(ColorBlue), 7)
+	blue3 := newNumberCard(9, uint32(ColorBlue), 3)
+	redPlus2, bluePlus2, plus4 := newPlus2Card(5, uint32(ColorRed)), newPlus2Card(6, uint32(ColorBlue)), newPlus4Card(7)
+	draw := simMove{kind: MoveDraw}
+	play := func(card ICard) simMove {
+		return simMove{kind: MovePlay, color: card.Color(), number: card.Number()}
+	}
+	plus4Moves := func(color ...Color) []simMove {
+		var moves []simMove
+		for _, c := range color {
+			moves = append(moves, simMove{kind: MovePlay, color: ColorBlack, number: 14, wantColor: c})
+		}
+		return moves
+	}
+	tests := []struct {
+		name    string
+		hand    []ICard
+		last    ICard
+		pending int
+		rules   Rules
+		drawn   ICard
+		want    []simMove
+	}{
+		{"同色和同点数", []ICard{red5, blue3, blue7}, red3, 0, Rules{}, nil, []simMove{draw, play(red5), play(blue3)}},
+		{"同样的牌只算一种行动", []ICard{red5, newNumberCard(8, uint32(ColorRed), 5)}, red3, 0, Rules{}, nil, []simMove{draw, play(red5)}},
+		{"有别的牌能打时不能打+4", []ICard{red5, plus4}, red3, 0, Rules{}, nil, []simMove{draw, play(red5)}},
+		{"没有别的牌能打时+4每种颜色各算一种", []ICard{blue7, plus4}, red3, 0, Rules{}, nil, append([]simMove{draw}, plus4Moves(ColorRed, ColorGreen, ColorYellow, ColorBlue)...)},
+		{"官方规则下+4随时能打", []ICard{red5, plus4}, red3, 0, Rules{ChallengePlus4: true}, nil, append([]simMove{draw, play(red5)}, plus4Moves(ColorRed, ColorGreen, ColorYellow, ColorBlue)...)},
+		{"不能叠加时罚摸牌只能摸", []ICard{bluePlus2, red5}, redPlus2, 2, Rules{}, nil, []simMove{draw}},
+		{"可以叠加+2", []ICard{bluePlus2, red5}, redPlus2, 2, Rules{StackPlus2: true}, nil, []simMove{draw, play(bluePlus2)}},
+		{"摸到的牌可以打出也可以不出", []ICard{red5, blue5}, red3, 0, Rules{}, red5, []simMove{play(red5), {kind: MovePass}}},
+		{"强制出牌时摸到的牌必须打出", []ICard{red5, blue5}, red3, 0, Rules{ForcePlay: true}, red5, []simMove{play(red5)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := testView(tt.hand, tt.last, tt.last.Color(), []int{len(tt.hand), 7}, tt.pending, tt.rules)
+			v.DrawnCard = tt.drawn
+			if got := determinize(v, rand.New(rand.NewSource(1))).legalMoves(); !slices.Equal(got, tt.want) {
+				t.Errorf("legalMoves() = %+v，应该是%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMctsChooseMove(t *testing.T) {
+	red3, red5, blue1, yellow2 := newNumberCard(1, uint32(ColorRed), 3), newNumberCard(2, uint32(ColorRed), 5), newNumberCard(3, uint32(ColorBlue), 1), newNumberCard(4, uint32(ColorYellow), 2)
+	wild, redPlus2 := newWildCard(5), newPlus2Card(6, uint32(ColorRed))
+	tests := []struct {
+		name    string
+		hand    []ICard
+		last    ICard
+		pending int
+		kind    MoveKind
+		card    ICard
+	}{
+		{"最后一张牌能打就打出", []ICard{red5}, red3, 0, MovePlay, red5},
+		{"最后一张变色牌", []ICard{wild}, red3, 0, MovePlay, wild},
+		{"能打完手牌时先打能打的", []ICard{red5, wild}, red3, 0, MovePlay, red5},
+		{"没有能打的牌只能摸牌", []ICard{blue1, yellow2}, red3, 0, MoveDraw, nil},
+		{"不能叠加时罚摸牌只能摸", []ICard{red5}, redPlus2, 2, MoveDraw, nil},
+	}
+	s := &mctsStrategy{iterations: 200}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			move := s.ChooseMove(testView(tt.hand, tt.last, tt.last.Color(), []int{len(tt.hand), 2, 2}, tt.pending, Rules{}))
+			if move.Kind != tt.kind || move.Card != tt.card {
+				t.Fatalf("ChooseMove() = %+v，应该是%d %v", move, tt.kind, tt.card)
+			}
+			if move.Card != nil && move.Card.Color() == ColorBlack && (move.WantColor < ColorRed || move.WantColor > ColorBlue) {
+				t.Errorf("黑牌选择的颜色%d不合法", move.WantColor)
+			}
+		})
+	}
+}
+
+// TestMctsReproducible 不限时的时候，同样的种子要做出同样的决定
+func TestMctsReproducible(t *testing.T) {
+	var hand []ICard
+	for i, color := range allColors {
+		hand = append(hand, newNumberCard(uint32(i*2+1), uint32(color), 5), newPlus2Card(uint32(i*2+2), uint32(color)))
+	}
+	last := newNumberCard(100, uint32(ColorRed), 5)
+	s := &mctsStrategy{iterations: 300}
+	for seed := int64(1); seed <= 5; seed++ {
+		var moves []Move
+		for range 2 {
+			v := testView(hand, last, ColorRed, []int{len(hand), 3, 3}, 0, Rules{})
+			v.Random = rand.New(rand.NewSource(seed))
+			moves = append(moves, s.ChooseMove(v))
+		}
+		if moves[0] != moves[1] {
+			t.Errorf("种子%d：两次的决定不一样：%+v %+v", seed, moves[0], moves[1])
+		}
+	}
+}
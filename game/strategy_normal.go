@@ -0,0 +1,45 @@
+package game
+
+func init() {
+	RegisterStrategy("normal", func() Strategy { return new(normalStrategy) })
+}
+
+// normalStrategy 普通的机器人：先出功能牌，再出数字牌，最后才出黑牌，黑牌选择手里最多的颜色
+type normalStrategy struct{}
+
+func (s *normalStrategy) Name() string {
+	return "normal"
+}
+
+func (s *normalStrategy) ChooseMove(v *View) Move {
+	catch := v.UnoVulnerable >= 0
+	if v.ChallengeFrom >= 0 {
+		// 上家手牌越多，越有可能是违规打出的+4
+		if v.HandCounts[v.ChallengeFrom] >= 5 {
+			return Move{Kind: MoveChallenge, CatchUno: catch}
+		}
+		return Move{Kind: MoveAccept, CatchUno: catch}
+	}
+	counts := v.ColorCounts()
+	wantColor := ColorRed
+	for color := ColorGreen; color <= ColorBlue; color++ {
+		if counts[color] > counts[wantColor] {
+			wantColor = color
+		}
+	}
+	target := v.FewestCardsOpponent()
+	playable := v.Playable(wantColor, target)
+	for _, match := range []func(card ICard) bool{
+		func(card ICard) bool { return card.Color() != ColorBlack && card.Number() >= 10 },
+		func(card ICard) bool { return card.Color() != ColorBlack && card.Number() < 10 },
+		func(card ICard) bool { return card.Number() == 13 },
+		func(card ICard) bool { return card.Number() == 14 },
+	} {
+		for _, card := range playable {
+			if match(card) {
+				return Move{Kind: MovePlay, Card: card, WantColor: wantColor, Target: target, CallUno: callUno(v, 0.1), CatchUno: catch}
+			}
+		}
+	}
+	return giveUpMove(v, catch)
+}
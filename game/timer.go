@@ -0,0 +1,72 @@
+package game
+
+import (
+	"fmt"
+	"github.com/CuteReimu/uno-server/config"
+	"time"
+)
+
+// startTurnTimer 开始当前回合的计时，只有玩家的回合才限时。超时后按配置替他摸牌或者由机器人代打
+func (game *Game) startTurnTimer() {
+	game.turnSeq++
+	timeout := time.Duration(config.GlobalConfig.GetInt("turn.timeout")) * time.Second
+	player, ok := game.Players[game.WhoseTurn].(*HumanPlayer)
+	if timeout <= 0 || !ok {
+		game.turnDeadline = time.Time{}
+		return
+	}
+	game.turnStart = game.Clock.Now()
+	game.turnDeadline = game.turnStart.Add(timeout + player.timeBank)
+	seq := game.turnSeq
+	game.turnTimer = game.Clock.AfterFunc(game.turnDeadline.Sub(game.turnStart), func() {
+		game.Post(func() {
+			if game.phase != PhasePlaying || game.turnSeq != seq || game.Players[game.WhoseTurn] != player {
+				return
+			}
+			game.turnTimer = nil
+			player.timeBank = 0
+			logger.Info(fmt.Sprintf("%d号玩家超时", player.location))
+			if config.GlobalConfig.GetString("turn.timeout_action") == "robot" {
+				player.robotAct(defaultStrategy())
+			} else {
+				player.timeoutDraw()
+			}
+		})
+	})
+}
+
+// stopTurnTimer 当前回合结束，停止计时。超出回合限时的部分从备用时间中扣除
+func (game *Game) stopTurnTimer() {
+	if game.turnTimer == nil {
+		return
+	}
+	game.turnTimer.Stop()
+	game.turnTimer = nil
+	if game.WhoseTurn >= len(game.Players) {
+		return
+	}
+	timeout := time.Duration(config.GlobalConfig.GetInt("turn.timeout")) * time.Second
+	if player, ok := game.Players[game.WhoseTurn].(*HumanPlayer); ok {
+		if overtime := game.Clock.Now().Sub(game.turnStart) - timeout; overtime > 0 {
+			player.timeBank = max(player.timeBank-overtime, 0)
+		}
+	}
+}
+
+// timeoutDraw 超时后替玩家摸牌并且不出，如果在等他决定是否质疑+4，就当作不质疑
+func (p *basePlayer) timeoutDraw() {
+	if p.game.plus4Challenge != nil {
+		p.ChallengePlus4(false)
+		return
+	}
+	if p.game.drawnCard == nil {
+		p.PlayCard(0)
+	}
+	if card := p.game.drawnCard; card != nil && p.game.WhoseTurn == p.location {
+		if p.game.Rules.ForcePlay {
+			p.PlayCard(card.Id(), p.autoArgs(card)...)
+		} else {
+			p.Pass()
+		}
+	}
+}
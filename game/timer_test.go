@@ -0,0 +1,139 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// newTimedGame 两个玩家的房间，每回合限时10秒，备用时间5秒，现在刚轮到0号
+func newTimedGame(t *testing.T, action string, rules Rules) (*Game, *testQueue, *testClock) {
+	setConfig(t, "turn.timeout", 10)
+	setConfig(t, "turn.time_bank", 5)
+	setConfig(t, "turn.timeout_action", action)
+	g, q, c, _ := newTestGame(t, 0, 2, rules)
+	g.WhoseTurn = 0
+	g.PendingDraw = 0
+	g.NextPlayer(0)
+	return g, q, c
+}
+
+func TestTurnDeadline(t *testing.T) {
+	g, _, c := newTimedGame(t, "draw", Rules{})
+	if want := c.Now().Add(15 * time.Second); !g.turnDeadline.Equal(want) {
+		t.Errorf("截止时间应该是回合限时加上备用时间，%v，实际是%v", want, g.turnDeadline)
+	}
+	setConfig(t, "turn.timeout", 0)
+	g.NextPlayer(1)
+	if !g.turnDeadline.IsZero() || g.turnTimer != nil {
+		t.Error("不限时的时候不应该计时")
+	}
+
+	setConfig(t, "turn.timeout", 10)
+	g, _, _, _ = newTestGame(t, 1, 1, Rules{})
+	g.WhoseTurn = 0
+	g.NextPlayer(0)
+	if _, ok := g.Players[0].(*RobotPlayer); !ok || !g.turnDeadline.IsZero() || g.turnTimer != nil {
+		t.Error("机器人的回合不应该计时")
+	}
+}
+
+func TestTimeBank(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed time.Duration // 0号用了多久才行动
+		want    time.Duration // 之后剩下的备用时间
+	}{
+		{"回合限时内行动", 8 * time.Second, 5 * time.Second},
+		{"正好用完回合限时", 10 * time.Second, 5 * time.Second},
+		{"用了一部分备用时间", 12 * time.Second, 3 * time.Second},
+		{"差一点用完备用时间", 14900 * time.Millisecond, 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, c := newTimedGame(t, "draw", Rules{})
+			player := g.Players[0].(*HumanPlayer)
+			c.now = c.now.Add(tt.elapsed)
+			g.NextPlayer(1)
+			if player.timeBank != tt.want {
+				t.Errorf("剩下的备用时间是%v，应该是%v", player.timeBank, tt.want)
+			}
+			// 下一回合的截止时间要算上剩下的备用时间
+			g.WhoseTurn = 1
+			g.NextPlayer(-1)
+			if want := c.Now().Add(10*time.Second + tt.want); !g.turnDeadline.Equal(want) {
+				t.Errorf("下一回合的截止时间应该是%v，实际是%v", want, g.turnDeadline)
+			}
+		})
+	}
+}
+
+func TestTurnTimeout(t *testing.T) {
+	tests := []struct {
+		name      string
+		action    string
+		rules     Rules
+		challenge bool // 超时的时候在等0号决定是否质疑+4
+		wantDraw  int  // 超时后0号至少多了几张牌，机器人代打时不确定，为-1
+	}{
+		{"替玩家摸牌", "draw", Rules{}, false, 1},
+		{"摸到的牌不出", "draw", Rules{DrawUntilPlayable: true}, false, 1},
+		{"机器人代打", "robot", Rules{}, false, -1},
+		{"当作不质疑+4", "draw", Rules{ChallengePlus4: true}, true, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, q, c := newTimedGame(t, tt.action, tt.rules)
+			player := g.Players[0].(*HumanPlayer)
+			if tt.challenge {
+				g.WhoseTurn = 1
+				newPlus4Card(1000).Execute(g, g.Players[1], uint32(ColorRed))
+				if g.plus4Challenge == nil || g.WhoseTurn != 0 {
+					t.Fatal("应该等0号决定是否质疑+4")
+				}
+			}
+			count := player.CardCount()
+			// 回合限时之内不会超时
+			c.now = c.now.Add(14 * time.Second)
+			q.drain()
+			if g.WhoseTurn != 0 {
+				t.Fatal("还没有超时就换人了")
+			}
+			seq := g.turnSeq
+			if !c.advance() {
+				t.Fatal("应该有超时的计时器")
+			}
+			q.drain()
+			// 机器人代打可能打出跳过，又轮到0号，所以只检查回合结束了
+			if g.turnSeq == seq || tt.wantDraw >= 0 && g.WhoseTurn != 1 {
+				t.Fatalf("超时后应该结束0号的回合，现在轮到%d号", g.WhoseTurn)
+			}
+			if player.timeBank != 0 {
+				t.Errorf("超时后备用时间应该用完了，还剩%v", player.timeBank)
+			}
+			if g.plus4Challenge != nil || g.drawnCard != nil {
+				t.Error("超时后等待的决定都应该作废")
+			}
+			if diff := player.CardCount() - count; diff < tt.wantDraw || diff == 0 {
+				t.Errorf("超时后0号的手牌从%d张变成了%d张", count, player.CardCount())
+			}
+		})
+	}
+}
+
+// TestStaleTurnTimer 玩家及时行动之后，这一回合的计时器不会再替他行动
+func TestStaleTurnTimer(t *testing.T) {
+	g, q, c := newTimedGame(t, "draw", Rules{})
+	player := g.Players[0].(*HumanPlayer)
+	g.NextPlayer(1)
+	count := player.CardCount()
+	// 1号也超时，只有他的计时器生效
+	for c.advance() {
+		q.drain()
+		if g.WhoseTurn == 0 {
+			break
+		}
+	}
+	if player.CardCount() != count || g.WhoseTurn != 0 {
+		t.Errorf("0号已经行动过了，不应该替他摸牌，手牌从%d张变成了%d张", count, player.CardCount())
+	}
+}
@@ -4,6 +4,7 @@ go 1.23.0
 
 require (
 	github.com/davyxu/cellnet v4.1.0+incompatible
+	github.com/gorilla/websocket v1.5.3
 	github.com/lestrrat-go/file-rotatelogs v2.4.0+incompatible
 	github.com/spf13/viper v1.21.0
 	google.golang.org/protobuf v1.36.11
@@ -0,0 +1,361 @@
+package lobby
+
+import (
+	"fmt"
+	"github.com/CuteReimu/uno-server/auth"
+	"github.com/CuteReimu/uno-server/config"
+	"github.com/CuteReimu/uno-server/core"
+	"github.com/CuteReimu/uno-server/game"
+	"github.com/CuteReimu/uno-server/protos"
+	"github.com/CuteReimu/uno-server/utils"
+	"github.com/davyxu/cellnet"
+	"github.com/davyxu/cellnet/msglog"
+	"github.com/davyxu/cellnet/peer"
+	_ "github.com/davyxu/cellnet/peer/gorillaws"
+	_ "github.com/davyxu/cellnet/peer/tcp"
+	"github.com/davyxu/cellnet/proc"
+	_ "github.com/davyxu/cellnet/proc/gorillaws"
+	_ "github.com/davyxu/cellnet/proc/tcp"
+	"maps"
+	"slices"
+	"time"
+)
+
+var logger = utils.GetLogger("lobby")
+
+// wsSessionIdBase WebSocket连接的会话ID从这里开始编号，避免和tcp连接的会话ID重复
+const wsSessionIdBase = 1 << 40
+
+// Lobby 大厅，一个侦听器下同时进行多个房间的游戏
+type Lobby struct {
+	cellnet.EventQueue
+	rooms       map[uint32]*Room
+	sessions    map[int64]cellnet.Session
+	sessionRoom map[int64]*Room
+	replays     map[int64]*replayer     // 正在回放的客户端
+	users       map[int64]game.Identity // 已经登录的客户端
+	auth        auth.Authenticator      // 不验证时为nil
+	nextRoomId  uint32
+}
+
+func New() *Lobby {
+	return &Lobby{
+		rooms:       make(map[uint32]*Room),
+		sessions:    make(map[int64]cellnet.Session),
+		sessionRoom: make(map[int64]*Room),
+		replays:     make(map[int64]*replayer),
+		users:       make(map[int64]game.Identity),
+	}
+}
+
+func (l *Lobby) Start() {
+	if !config.GlobalConfig.GetBool("log.tcp_debug_log") {
+		msglog.SetCurrMsgLogMode(msglog.MsgLogMode_Mute)
+	}
+	var err error
+	if l.auth, err = auth.New(); err != nil {
+		panic(fmt.Sprintf("创建登录验证器失败：%+v", err))
+	}
+	if err = game.LoadDeckSpec(); err != nil {
+		panic(fmt.Sprintf("牌堆配置错误：%+v", err))
+	}
+	// 创建一个事件处理队列，整个服务器只有这一个队列处理事件，所有房间共用，服务器属于单线程服务器
+	l.EventQueue = cellnet.NewEventQueue()
+
+	// 创建一个tcp的侦听器，名称为server，所有连接将事件投递到queue队列,单线程的处理
+	p := peer.NewGenericPeer("tcp.Acceptor", "server", config.GlobalConfig.GetString("listen_address"), l.EventQueue)
+	proc.BindProcessorHandler(p, processorName("tcp", "listen_codec"), l.handle)
+	p.Start()
+
+	// 浏览器客户端通过WebSocket连接，和tcp连接共用同一个队列和同样的协议
+	if address := config.GlobalConfig.GetString("ws_listen_address"); address != "" {
+		ws := peer.NewGenericPeer("gorillaws.Acceptor", "ws_server", address, l.EventQueue)
+		ws.(peer.SessionManager).SetIDBase(wsSessionIdBase)
+		proc.BindProcessorHandler(ws, processorName("gorillaws", "ws_listen_codec"), l.handle)
+		ws.Start()
+	}
+	l.StartLoop()
+	l.Wait()
+}
+
+// processorName 按配置项key指定的编码方式，返回侦听器使用的处理器名称
+func processorName(transport, key string) string {
+	name, ok := core.ProcessorName(transport, config.GlobalConfig.GetString(key))
+	if !ok {
+		panic(fmt.Sprintf("%s配置的编码方式不正确：%s", key, config.GlobalConfig.GetString(key)))
+	}
+	return name
+}
+
+func (l *Lobby) handle(ev cellnet.Event) {
+	session := ev.Session()
+	if l.needLogin(session, ev.Message()) {
+		logger.Error("还没有登录", "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_not_logged_in})
+		return
+	}
+	switch msg := ev.Message().(type) {
+	case *cellnet.SessionAccepted:
+		logger.Info("server accepted", "sessionId", session.ID())
+		l.sessions[session.ID()] = session
+		session.Send(l.roomList())
+	case *cellnet.SessionClosed:
+		logger.Info("session closed", "sessionId", session.ID())
+		l.stopReplay(session)
+		l.disconnect(session)
+		delete(l.sessions, session.ID())
+		delete(l.users, session.ID())
+	case *protos.LoginTos:
+		l.login(session, msg.Username, msg.Token, msg.AvatarId)
+	case *protos.CreateRoomTos:
+		l.createRoom(session, int(msg.PlayerNum), int(msg.RobotNum), msg.Rules, msg.RobotStrategies)
+	case *protos.JoinRoomTos:
+		l.joinRoom(session, msg.RoomId)
+	case *protos.LeaveRoomTos:
+		l.leaveRoom(session)
+	case *protos.SpectateTos:
+		l.spectate(session, msg.RoomId, msg.GodView)
+	case *protos.ReconnectTos:
+		l.reconnect(session, msg.Token)
+	case *protos.ReplayListTos:
+		l.replayList(session)
+	case *protos.ReplayTos:
+		l.replay(session, msg.ReplayId, msg.Speed)
+	case *protos.ReplaySpeedTos:
+		l.replaySpeed(session, msg.Speed)
+	default:
+		if room := l.sessionRoom[session.ID()]; room != nil {
+			room.Handle(session, msg)
+		}
+	}
+}
+
+// needLogin 开启了验证时，还没有登录的客户端只能登录
+func (l *Lobby) needLogin(session cellnet.Session, msg interface{}) bool {
+	if l.auth == nil {
+		return false
+	}
+	if _, ok := l.users[session.ID()]; ok {
+		return false
+	}
+	switch msg.(type) {
+	case *cellnet.SessionAccepted, *cellnet.SessionClosed, *protos.LoginTos:
+		return false
+	}
+	return true
+}
+
+func (l *Lobby) login(session cellnet.Session, username, token string, avatar uint32) {
+	if _, ok := l.users[session.ID()]; ok || l.sessionRoom[session.ID()] != nil {
+		logger.Error("已经登录了，不能重新登录", "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_already_logged_in})
+		return
+	}
+	identity := game.Identity{Id: username, Name: username, Avatar: avatar}
+	if l.auth != nil {
+		user, err := l.auth.Authenticate(username, token)
+		if err != nil {
+			logger.Error("登录失败："+username, "error", err, "sessionId", session.ID())
+			session.Send(&protos.ErrorToc{Code: protos.ErrorCode_login_failed})
+			return
+		}
+		identity = game.Identity{Id: user.Id, Name: user.Name, Avatar: user.Avatar}
+		if identity.Avatar == 0 {
+			identity.Avatar = avatar
+		}
+	} else if username == "" {
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_login_failed})
+		return
+	}
+	l.users[session.ID()] = identity
+	logger.Info(fmt.Sprintf("%s（%s）登录成功", identity.Name, identity.Id), "sessionId", session.ID())
+	session.Send(&protos.LoginToc{Identity: &protos.PlayerIdentity{UserId: identity.Id, Name: identity.Name}})
+}
+
+// identity 客户端的身份，没有登录的客户端是没有玩家ID的游客
+func (l *Lobby) identity(session cellnet.Session) game.Identity {
+	if identity, ok := l.users[session.ID()]; ok {
+		return identity
+	}
+	return game.Identity{Name: fmt.Sprintf("游客%d", session.ID())}
+}
+
+func (l *Lobby) createRoom(session cellnet.Session, totalCount, robotCount int, rules *protos.Rules, strategyNames []string) {
+	if l.sessionRoom[session.ID()] != nil {
+		logger.Error("已经在房间中，不能创建房间", "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_already_in_room})
+		return
+	}
+	if totalCount == 0 {
+		totalCount = config.GlobalConfig.GetInt("player.total_count")
+		robotCount = config.GlobalConfig.GetInt("player.robot_count")
+		if len(strategyNames) == 0 {
+			strategyNames = config.GlobalConfig.GetStringSlice("robot.seat_strategies")
+		}
+	}
+	if totalCount < 2 || totalCount > game.MaxPlayerCount || robotCount < 0 || robotCount >= totalCount {
+		logger.Error(fmt.Sprintf("房间人数错误，总人数：%d，机器人人数：%d", totalCount, robotCount), "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_invalid_room_config})
+		return
+	}
+	robots := make([]game.Strategy, robotCount)
+	for i := range robots {
+		var name string
+		if i < len(strategyNames) {
+			name = strategyNames[i]
+		}
+		strategy, err := game.NewStrategy(name)
+		if err != nil {
+			logger.Error("机器人策略错误", "error", err, "sessionId", session.ID())
+			session.Send(&protos.ErrorToc{Code: protos.ErrorCode_invalid_room_config})
+			return
+		}
+		robots[i] = strategy
+	}
+	roomRules := game.DefaultRules()
+	if rules != nil {
+		roomRules = game.RulesFromProto(rules)
+	}
+	l.nextRoomId++
+	room := &Room{Id: l.nextRoomId, Game: game.NewGame(l.EventQueue, totalCount, robots, roomRules)}
+	l.rooms[room.Id] = room
+	logger.Info(fmt.Sprintf("创建了%d号房间，总人数：%d，机器人：%v", room.Id, totalCount, room.RobotStrategies()), "sessionId", session.ID())
+	l.joinRoom(session, room.Id)
+}
+
+func (l *Lobby) joinRoom(session cellnet.Session, roomId uint32) {
+	if l.sessionRoom[session.ID()] != nil {
+		logger.Error("已经在房间中，不能加入其它房间", "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_already_in_room})
+		return
+	}
+	room := l.rooms[roomId]
+	if room == nil {
+		logger.Error(fmt.Sprintf("%d号房间不存在", roomId), "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_room_not_found})
+		return
+	}
+	if !room.Join(session, l.identity(session)) {
+		logger.Info(fmt.Sprintf("%d号房间人数已满", roomId), "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_room_full})
+		return
+	}
+	l.stopReplay(session)
+	l.sessionRoom[session.ID()] = room
+	session.Send(&protos.JoinRoomToc{RoomId: roomId})
+	l.broadcastRoomList()
+}
+
+func (l *Lobby) spectate(session cellnet.Session, roomId uint32, godView bool) {
+	if l.sessionRoom[session.ID()] != nil {
+		logger.Error("已经在房间中，不能观战", "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_already_in_room})
+		return
+	}
+	room := l.rooms[roomId]
+	if room == nil {
+		logger.Error(fmt.Sprintf("%d号房间不存在", roomId), "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_room_not_found})
+		return
+	}
+	if godView && game.GodViewDelay() <= 0 {
+		logger.Error("服务器不允许上帝视角观战", "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_god_view_disabled})
+		return
+	}
+	l.stopReplay(session)
+	l.sessionRoom[session.ID()] = room
+	msg := &protos.SpectateToc{RoomId: roomId}
+	if godView {
+		msg.GodViewDelay = uint32(game.GodViewDelay() / time.Second)
+	}
+	session.Send(msg)
+	room.Spectate(session, l.identity(session), godView)
+	l.broadcastRoomList()
+}
+
+func (l *Lobby) leaveRoom(session cellnet.Session) {
+	room := l.sessionRoom[session.ID()]
+	if room == nil {
+		return
+	}
+	delete(l.sessionRoom, session.ID())
+	room.Leave(session)
+	l.closeIfEmpty(room)
+	l.broadcastRoomList()
+}
+
+// disconnect 断线的玩家在游戏中的话，保留座位一段时间等待重连
+func (l *Lobby) disconnect(session cellnet.Session) {
+	room := l.sessionRoom[session.ID()]
+	if room == nil {
+		return
+	}
+	delete(l.sessionRoom, session.ID())
+	if token, seq, ok := room.Disconnect(session); ok {
+		timeout := time.Duration(config.GlobalConfig.GetInt("reconnect.timeout")) * time.Second
+		time.AfterFunc(timeout, func() {
+			l.Post(func() {
+				if l.rooms[room.Id] == room && room.ReleaseSeat(token, seq) {
+					l.closeIfEmpty(room)
+					l.broadcastRoomList()
+				}
+			})
+		})
+	}
+	l.closeIfEmpty(room)
+	l.broadcastRoomList()
+}
+
+func (l *Lobby) reconnect(session cellnet.Session, token string) {
+	if l.sessionRoom[session.ID()] != nil {
+		logger.Error("已经在房间中，不能断线重连", "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_already_in_room})
+		return
+	}
+	for _, room := range l.rooms {
+		if room.Reconnect(session, l.identity(session), token) {
+			l.stopReplay(session)
+			l.sessionRoom[session.ID()] = room
+			session.Send(&protos.JoinRoomToc{RoomId: room.Id})
+			room.Resync(session)
+			l.broadcastRoomList()
+			return
+		}
+	}
+	logger.Error("断线重连失败，座位已经不在了", "sessionId", session.ID())
+	session.Send(&protos.ErrorToc{Code: protos.ErrorCode_reconnect_failed})
+}
+
+// closeIfEmpty 房间里没有玩家了就解散房间
+func (l *Lobby) closeIfEmpty(room *Room) {
+	if room.HumanCount() == 0 {
+		room.Stop()
+		delete(l.rooms, room.Id)
+		// 观战者回到大厅，会收到房间列表
+		for id, r := range l.sessionRoom {
+			if r == room {
+				delete(l.sessionRoom, id)
+			}
+		}
+		logger.Info(fmt.Sprintf("%d号房间已经没人了，解散房间", room.Id))
+	}
+}
+
+func (l *Lobby) roomList() *protos.RoomListToc {
+	msg := &protos.RoomListToc{}
+	for _, roomId := range slices.Sorted(maps.Keys(l.rooms)) {
+		msg.Rooms = append(msg.Rooms, l.rooms[roomId].info())
+	}
+	return msg
+}
+
+// broadcastRoomList 把房间列表发给所有在大厅中的玩家
+func (l *Lobby) broadcastRoomList() {
+	msg := l.roomList()
+	for id, session := range l.sessions {
+		if l.sessionRoom[id] == nil {
+			session.Send(msg)
+		}
+	}
+}
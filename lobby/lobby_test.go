@@ -0,0 +1,253 @@
+package lobby
+
+import (
+	"github.com/CuteReimu/uno-server/config"
+	"github.com/CuteReimu/uno-server/game"
+	"github.com/CuteReimu/uno-server/protos"
+	"github.com/CuteReimu/uno-server/utils"
+	"github.com/davyxu/cellnet"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	utils.SetLogLevel(slog.LevelError + 1)
+	config.GlobalConfig.SetConfigFile(filepath.Join("..", "config.yaml"))
+	if err := config.GlobalConfig.ReadInConfig(); err != nil {
+		panic(err)
+	}
+	config.GlobalConfig.Set("replay.enabled", false)
+	os.Exit(m.Run())
+}
+
+// testQueue 同步的事件队列，由drain在当前goroutine中依次执行
+type testQueue struct {
+	cellnet.EventQueue
+	events []func()
+}
+
+func (q *testQueue) Post(callback func()) { q.events = append(q.events, callback) }
+
+func (q *testQueue) drain() {
+	for len(q.events) > 0 {
+		callback := q.events[0]
+		q.events = q.events[1:]
+		callback()
+	}
+}
+
+// testClock 不会触发的时钟，机器人和计时器都不会行动
+type testClock struct{}
+
+type testTimer struct{}
+
+func (testTimer) Stop() bool                                 { return true }
+func (testClock) Now() time.Time                             { return time.Unix(0, 0) }
+func (testClock) AfterFunc(time.Duration, func()) game.Timer { return testTimer{} }
+
+// testSession 把发给客户端的消息都记下来
+type testSession struct {
+	cellnet.Session
+	id   int64
+	sent []interface{}
+}
+
+func (s *testSession) ID() int64            { return s.id }
+func (s *testSession) Send(msg interface{}) { s.sent = append(s.sent, msg) }
+
+// lastError 最后收到的错误码，没有收到过错误则为success
+func (s *testSession) lastError() protos.ErrorCode {
+	for i := len(s.sent) - 1; i >= 0; i-- {
+		if msg, ok := s.sent[i].(*protos.ErrorToc); ok {
+			return msg.Code
+		}
+	}
+	return protos.ErrorCode_success
+}
+
+// joined 最后收到的join_room_toc中的房间号，没有收到过则为0
+func (s *testSession) joined() uint32 {
+	for i := len(s.sent) - 1; i >= 0; i-- {
+		if msg, ok := s.sent[i].(*protos.JoinRoomToc); ok {
+			return msg.RoomId
+		}
+	}
+	return 0
+}
+
+type testLobby struct {
+	*Lobby
+	q *testQueue
+}
+
+func newTestLobby() *testLobby {
+	l := &testLobby{Lobby: New(), q: new(testQueue)}
+	l.EventQueue = l.q
+	return l
+}
+
+// send 客户端连上并发来msg
+func (l *testLobby) send(t *testing.T, session *testSession, msg interface{}) {
+	t.Helper()
+	if _, ok := l.sessions[session.ID()]; !ok {
+		l.handle(&cellnet.RecvMsgEvent{Ses: session, Msg: &cellnet.SessionAccepted{}})
+	}
+	l.handle(&cellnet.RecvMsgEvent{Ses: session, Msg: msg})
+	// 新创建的房间换成不会触发的时钟
+	for _, room := range l.rooms {
+		room.Clock = testClock{}
+	}
+	l.q.drain()
+}
+
+func TestCreateRoom(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *protos.CreateRoomTos
+		want protos.ErrorCode
+	}{
+		{"默认配置", &protos.CreateRoomTos{}, protos.ErrorCode_success},
+		{"指定人数", &protos.CreateRoomTos{PlayerNum: 3, RobotNum: 1, RobotStrategies: []string{"hard"}}, protos.ErrorCode_success},
+		{"只有一个人", &protos.CreateRoomTos{PlayerNum: 1}, protos.ErrorCode_invalid_room_config},
+		{"人数太多", &protos.CreateRoomTos{PlayerNum: game.MaxPlayerCount + 1}, protos.ErrorCode_invalid_room_config},
+		{"全是机器人", &protos.CreateRoomTos{PlayerNum: 2, RobotNum: 2}, protos.ErrorCode_invalid_room_config},
+		{"不存在的策略", &protos.CreateRoomTos{PlayerNum: 2, RobotNum: 1, RobotStrategies: []string{"foo"}}, protos.ErrorCode_invalid_room_config},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newTestLobby()
+			session := &testSession{id: 1}
+			l.send(t, session, tt.msg)
+			if code := session.lastError(); code != tt.want {
+				t.Fatalf("创建房间的结果是%s，应该是%s", code, tt.want)
+			}
+			if tt.want != protos.ErrorCode_success {
+				if len(l.rooms) != 0 || session.joined() != 0 {
+					t.Error("创建失败时不应该有房间")
+				}
+				return
+			}
+			room := l.rooms[session.joined()]
+			if room == nil || l.sessionRoom[session.ID()] != room || room.HumanCount() != 1 {
+				t.Fatal("创建房间后应该坐在新房间里")
+			}
+			if tt.msg.PlayerNum != 0 && (room.TotalPlayerCount != int(tt.msg.PlayerNum) || room.RobotCount != int(tt.msg.RobotNum)) {
+				t.Errorf("房间有%d人，其中%d个机器人", room.TotalPlayerCount, room.RobotCount)
+			}
+			l.send(t, session, &protos.CreateRoomTos{})
+			if session.lastError() != protos.ErrorCode_already_in_room || len(l.rooms) != 1 {
+				t.Error("已经在房间里时不能再创建房间")
+			}
+		})
+	}
+}
+
+func TestJoinAndLeaveRoom(t *testing.T) {
+	l := newTestLobby()
+	host, guest, late := &testSession{id: 1}, &testSession{id: 2}, &testSession{id: 3}
+	l.send(t, host, &protos.CreateRoomTos{PlayerNum: 3, RobotNum: 1})
+	roomId := host.joined()
+	tests := []struct {
+		name    string
+		session *testSession
+		msg     interface{}
+		want    protos.ErrorCode
+		humans  int // 之后房间里的玩家人数，房间解散了则为0
+	}{
+		{"房间不存在", guest, &protos.JoinRoomTos{RoomId: roomId + 1}, protos.ErrorCode_room_not_found, 1},
+		{"加入房间", guest, &protos.JoinRoomTos{RoomId: roomId}, protos.ErrorCode_success, 2},
+		{"已经在房间里", guest, &protos.JoinRoomTos{RoomId: roomId}, protos.ErrorCode_already_in_room, 2},
+		{"房间满了", late, &protos.JoinRoomTos{RoomId: roomId}, protos.ErrorCode_room_full, 2},
+		{"房主离开", host, &protos.LeaveRoomTos{}, protos.ErrorCode_success, 1},
+		{"空出座位后可以加入", late, &protos.JoinRoomTos{RoomId: roomId}, protos.ErrorCode_success, 2},
+		{"断线", guest, &cellnet.SessionClosed{}, protos.ErrorCode_success, 1},
+		{"最后一个人离开", late, &protos.LeaveRoomTos{}, protos.ErrorCode_success, 0},
+	}
+	for _, tt := range tests {
+		before := len(tt.session.sent)
+		l.send(t, tt.session, tt.msg)
+		code := protos.ErrorCode_success
+		for _, msg := range tt.session.sent[before:] {
+			if e, ok := msg.(*protos.ErrorToc); ok {
+				code = e.Code
+			}
+		}
+		if code != tt.want {
+			t.Fatalf("%s：结果是%s，应该是%s", tt.name, code, tt.want)
+		}
+		humans := 0
+		if room := l.rooms[roomId]; room != nil {
+			humans = room.HumanCount()
+		}
+		if humans != tt.humans {
+			t.Fatalf("%s：房间里有%d人，应该有%d人", tt.name, humans, tt.humans)
+		}
+	}
+	if len(l.rooms) != 0 || len(l.sessionRoom) != 0 {
+		t.Error("没人的房间应该解散")
+	}
+}
+
+func TestCloseRoomWithSpectators(t *testing.T) {
+	l := newTestLobby()
+	player, spectator := &testSession{id: 1}, &testSession{id: 2}
+	l.send(t, player, &protos.CreateRoomTos{PlayerNum: 2, RobotNum: 1})
+	l.send(t, spectator, &protos.SpectateTos{RoomId: player.joined()})
+	if l.sessionRoom[spectator.ID()] == nil {
+		t.Fatal("观战失败")
+	}
+	l.send(t, player, &protos.LeaveRoomTos{})
+	if len(l.rooms) != 0 || l.sessionRoom[spectator.ID()] != nil {
+		t.Error("玩家都离开后应该解散房间，观战者回到大厅")
+	}
+}
+
+func TestReconnectToRightRoom(t *testing.T) {
+	l := newTestLobby()
+	var tokens []string
+	for i := range 3 {
+		session := &testSession{id: int64(i + 1)}
+		l.send(t, session, &protos.CreateRoomTos{PlayerNum: 2, RobotNum: 1})
+		l.send(t, session, &protos.ReadyTos{Ready: true})
+		if !l.sessionRoom[session.ID()].IsPlaying() {
+			t.Fatal("准备好后应该开局")
+		}
+		var token string
+		for _, msg := range session.sent {
+			if init, ok := msg.(*protos.InitToc); ok {
+				token = init.ReconnectToken
+			}
+		}
+		tokens = append(tokens, token)
+		l.send(t, session, &cellnet.SessionClosed{})
+	}
+	if len(l.rooms) != 3 {
+		t.Fatalf("断线等待重连时应该保留房间，现在有%d个房间", len(l.rooms))
+	}
+	tests := []struct {
+		name  string
+		token string
+		room  uint32
+	}{
+		{"错误的凭证", "foo", 0},
+		{"第2个房间", tokens[1], 2},
+		{"第1个房间", tokens[0], 1},
+		{"已经重连过了", tokens[1], 0},
+	}
+	for i, tt := range tests {
+		session := &testSession{id: int64(100 + i)}
+		l.send(t, session, &protos.ReconnectTos{Token: tt.token})
+		if tt.room == 0 {
+			if session.lastError() != protos.ErrorCode_reconnect_failed || l.sessionRoom[session.ID()] != nil {
+				t.Errorf("%s：应该重连失败", tt.name)
+			}
+			continue
+		}
+		if session.joined() != tt.room || l.sessionRoom[session.ID()] != l.rooms[tt.room] {
+			t.Errorf("%s：应该回到%d号房间，实际是%d号房间", tt.name, tt.room, session.joined())
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package lobby
+
+import (
+	"github.com/CuteReimu/uno-server/game"
+	"github.com/CuteReimu/uno-server/protos"
+	"github.com/davyxu/cellnet"
+	"time"
+)
+
+// replayer 按记录的时间间隔，给一个客户端逐个发送一局游戏的事件
+type replayer struct {
+	lobby   *Lobby
+	session cellnet.Session
+	record  *game.Record
+	next    int     // 下一个要发送的事件
+	speed   float64 // 播放速度，为0表示暂停
+	timer   *time.Timer
+}
+
+func (l *Lobby) replayList(session cellnet.Session) {
+	ids, err := game.RecordIds()
+	if err != nil {
+		logger.Error("读取回放列表失败", "error", err, "sessionId", session.ID())
+	}
+	session.Send(&protos.ReplayListToc{ReplayIds: ids})
+}
+
+func (l *Lobby) replay(session cellnet.Session, id string, speed float64) {
+	if l.sessionRoom[session.ID()] != nil {
+		logger.Error("已经在房间中，不能回放", "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_already_in_room})
+		return
+	}
+	l.stopReplay(session)
+	path, ok := game.RecordPath(id)
+	if !ok {
+		logger.Error("回放的ID不合法："+id, "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_replay_not_found})
+		return
+	}
+	record, err := game.LoadRecord(path)
+	if err != nil {
+		logger.Error("读取回放失败", "error", err, "sessionId", session.ID())
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_replay_not_found})
+		return
+	}
+	if speed <= 0 {
+		speed = 1
+	}
+	r := &replayer{lobby: l, session: session, record: record, speed: speed}
+	l.replays[session.ID()] = r
+	logger.Info("开始回放"+id, "sessionId", session.ID())
+	session.Send(&protos.ReplayStartToc{
+		ReplayId:   record.Id,
+		Seed:       record.Seed,
+		PlayerNum:  uint32(record.PlayerCount),
+		Players:    record.Players,
+		Rules:      record.Rules.ToProto(),
+		Identities: identitiesToProto(record.Identities),
+	})
+	r.schedule()
+}
+
+func (l *Lobby) replaySpeed(session cellnet.Session, speed float64) {
+	r := l.replays[session.ID()]
+	if r == nil {
+		session.Send(&protos.ErrorToc{Code: protos.ErrorCode_not_replaying})
+		return
+	}
+	r.speed = speed
+	r.schedule()
+}
+
+// stopReplay 停止正在进行的回放
+func (l *Lobby) stopReplay(session cellnet.Session) {
+	if r := l.replays[session.ID()]; r != nil {
+		r.stopTimer()
+		delete(l.replays, session.ID())
+	}
+}
+
+func (r *replayer) stopTimer() {
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
+
+// schedule 按照和上一个事件的时间间隔，安排发送下一个事件。暂停的时候什么也不做
+func (r *replayer) schedule() {
+	r.stopTimer()
+	if r.speed <= 0 {
+		return
+	}
+	events := r.record.Events
+	if r.next >= len(events) {
+		r.session.Send(&protos.ReplayEndToc{ReplayId: r.record.Id})
+		delete(r.lobby.replays, r.session.ID())
+		return
+	}
+	var wait time.Duration
+	if r.next > 0 {
+		wait = time.Duration(float64(events[r.next].Time-events[r.next-1].Time) / r.speed * float64(time.Millisecond))
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(wait, func() {
+		r.lobby.Post(func() {
+			if r.lobby.replays[r.session.ID()] != r || r.timer != timer {
+				return
+			}
+			r.timer = nil
+			r.session.Send(eventToProto(events[r.next]))
+			r.next++
+			r.schedule()
+		})
+	})
+	r.timer = timer
+}
+
+func identitiesToProto(identities []game.Identity) []*protos.PlayerIdentity {
+	result := make([]*protos.PlayerIdentity, 0, len(identities))
+	for _, identity := range identities {
+		result = append(result, &protos.PlayerIdentity{UserId: identity.Id, Name: identity.Name})
+	}
+	return result
+}
+
+func eventToProto(e game.Event) *protos.ReplayEventToc {
+	msg := &protos.ReplayEventToc{
+		Time:           e.Time,
+		Type:           e.Type,
+		PlayerId:       uint32(e.Player),
+		TargetPlayerId: uint32(e.Target),
+		WantColor:      uint32(e.WantColor),
+		Dir:            e.Dir,
+		Count:          int32(e.Count),
+		Success:        e.Success,
+		Text:           e.Text,
+	}
+	for _, card := range e.Cards {
+		msg.Cards = append(msg.Cards, &protos.UnoCard{CardId: card.Id, Color: uint32(card.Color), Num: card.Num})
+	}
+	return msg
+}
@@ -0,0 +1,24 @@
+package lobby
+
+import (
+	"github.com/CuteReimu/uno-server/game"
+	"github.com/CuteReimu/uno-server/protos"
+)
+
+type Room struct {
+	Id uint32
+	*game.Game
+}
+
+func (r *Room) info() *protos.RoomInfo {
+	return &protos.RoomInfo{
+		RoomId:          r.Id,
+		PlayerNum:       uint32(r.TotalPlayerCount),
+		RobotNum:        uint32(r.RobotCount),
+		HumanNum:        uint32(r.HumanCount()),
+		Playing:         r.IsPlaying(),
+		Rules:           r.Rules.ToProto(),
+		RobotStrategies: r.RobotStrategies(),
+		SpectatorNum:    uint32(r.SpectatorCount()),
+	}
+}
@@ -1,13 +1,9 @@
 package main
 
 import (
-	"github.com/CuteReimu/uno-server/config"
-	"github.com/CuteReimu/uno-server/game"
+	"github.com/CuteReimu/uno-server/lobby"
 )
 
 func main() {
-	totalCount := config.GlobalConfig.GetInt("player.total_count")
-	robotCount := config.GlobalConfig.GetInt("player.robot_count")
-	g := &game.Game{}
-	g.Start(totalCount, robotCount)
+	lobby.New().Start()
 }
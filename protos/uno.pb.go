@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.26.0
+// 	protoc-gen-go v1.36.11
 // 	protoc        v3.11.4
 // source: uno.proto
 
@@ -11,6 +11,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -20,24 +21,282 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 房间所处的阶段
+type GamePhase int32
+
+const (
+	GamePhase_phase_waiting    GamePhase = 0 // 等待玩家入座和准备
+	GamePhase_phase_dealing    GamePhase = 1 // 正在洗牌发牌
+	GamePhase_phase_playing    GamePhase = 2 // 一局正在进行
+	GamePhase_phase_round_over GamePhase = 3 // 一局结束，等待下一局开始
+)
+
+// Enum value maps for GamePhase.
+var (
+	GamePhase_name = map[int32]string{
+		0: "phase_waiting",
+		1: "phase_dealing",
+		2: "phase_playing",
+		3: "phase_round_over",
+	}
+	GamePhase_value = map[string]int32{
+		"phase_waiting":    0,
+		"phase_dealing":    1,
+		"phase_playing":    2,
+		"phase_round_over": 3,
+	}
+)
+
+func (x GamePhase) Enum() *GamePhase {
+	p := new(GamePhase)
+	*p = x
+	return p
+}
+
+func (x GamePhase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GamePhase) Descriptor() protoreflect.EnumDescriptor {
+	return file_uno_proto_enumTypes[0].Descriptor()
+}
+
+func (GamePhase) Type() protoreflect.EnumType {
+	return &file_uno_proto_enumTypes[0]
+}
+
+func (x GamePhase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GamePhase.Descriptor instead.
+func (GamePhase) EnumDescriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{0}
+}
+
+// 错误码
+type ErrorCode int32
+
+const (
+	ErrorCode_success                 ErrorCode = 0  // 没有错误
+	ErrorCode_not_your_turn           ErrorCode = 1  // 还没到你的回合
+	ErrorCode_no_such_card            ErrorCode = 2  // 你没有这张牌
+	ErrorCode_card_not_match          ErrorCode = 3  // 颜色和数字（或功能）都对不上，不能打这张牌
+	ErrorCode_invalid_want_color      ErrorCode = 4  // 打黑牌时没有选择颜色，或者选择的颜色不合法
+	ErrorCode_plus4_not_allowed       ErrorCode = 5  // 手里还有能打的牌，不能打+4
+	ErrorCode_already_in_room         ErrorCode = 6  // 已经在房间中了
+	ErrorCode_room_not_found          ErrorCode = 7  // 房间不存在
+	ErrorCode_room_full               ErrorCode = 8  // 房间人数已满
+	ErrorCode_invalid_room_config     ErrorCode = 9  // 创建房间时人数不合法
+	ErrorCode_reconnect_failed        ErrorCode = 10 // 断线重连失败，座位已经不在了
+	ErrorCode_invalid_target          ErrorCode = 11 // 打出7时选择的交换手牌的玩家不合法
+	ErrorCode_must_stack_or_draw      ErrorCode = 12 // 正在累积罚摸牌，只能接着打出+2/+4或者摸牌
+	ErrorCode_cannot_call_uno         ErrorCode = 13 // 手牌多于两张，不能喊UNO
+	ErrorCode_catch_uno_failed        ErrorCode = 14 // 这个玩家没有忘记喊UNO，或者已经过了抓他的时机
+	ErrorCode_must_answer_challenge   ErrorCode = 15 // 要先决定是否质疑上家打出的+4
+	ErrorCode_no_challenge            ErrorCode = 16 // 现在没有可以质疑的+4
+	ErrorCode_must_play_drawn_or_pass ErrorCode = 17 // 摸牌后只能打出刚摸到的牌，或者选择不出
+	ErrorCode_cannot_pass             ErrorCode = 18 // 现在不能选择不出
+	ErrorCode_replay_not_found        ErrorCode = 19 // 回放不存在或者读取失败
+	ErrorCode_not_replaying           ErrorCode = 20 // 现在没有正在进行的回放
+	ErrorCode_not_logged_in           ErrorCode = 21 // 服务器开启了验证，要先登录
+	ErrorCode_login_failed            ErrorCode = 22 // 用户名或者登录凭证不正确
+	ErrorCode_already_logged_in       ErrorCode = 23 // 已经登录了，或者在房间中不能重新登录
+	ErrorCode_god_view_disabled       ErrorCode = 24 // 服务器不允许上帝视角观战
+	ErrorCode_chat_too_fast           ErrorCode = 25 // 发言太频繁了
+	ErrorCode_chat_too_long           ErrorCode = 26 // 聊天消息太长了
+	ErrorCode_invalid_emote           ErrorCode = 27 // 表情不存在
+	ErrorCode_not_host                ErrorCode = 28 // 只有房主可以提前开局
+	ErrorCode_not_all_ready           ErrorCode = 29 // 还有玩家没有准备好
+	ErrorCode_not_waiting             ErrorCode = 30 // 已经开局了，不能准备或者提前开局
+	ErrorCode_game_not_started        ErrorCode = 31 // 还没有开局，不能投票重开
+	ErrorCode_stale_request           ErrorCode = 32 // 请求的那一局已经结束了
+	ErrorCode_chat_empty              ErrorCode = 33 // 聊天消息是空的
+	ErrorCode_not_playing             ErrorCode = 34 // 这一局还没开始或者已经结束了，不能出牌、摸牌、喊UNO、抓UNO或者质疑
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0:  "success",
+		1:  "not_your_turn",
+		2:  "no_such_card",
+		3:  "card_not_match",
+		4:  "invalid_want_color",
+		5:  "plus4_not_allowed",
+		6:  "already_in_room",
+		7:  "room_not_found",
+		8:  "room_full",
+		9:  "invalid_room_config",
+		10: "reconnect_failed",
+		11: "invalid_target",
+		12: "must_stack_or_draw",
+		13: "cannot_call_uno",
+		14: "catch_uno_failed",
+		15: "must_answer_challenge",
+		16: "no_challenge",
+		17: "must_play_drawn_or_pass",
+		18: "cannot_pass",
+		19: "replay_not_found",
+		20: "not_replaying",
+		21: "not_logged_in",
+		22: "login_failed",
+		23: "already_logged_in",
+		24: "god_view_disabled",
+		25: "chat_too_fast",
+		26: "chat_too_long",
+		27: "invalid_emote",
+		28: "not_host",
+		29: "not_all_ready",
+		30: "not_waiting",
+		31: "game_not_started",
+		32: "stale_request",
+		33: "chat_empty",
+		34: "not_playing",
+	}
+	ErrorCode_value = map[string]int32{
+		"success":                 0,
+		"not_your_turn":           1,
+		"no_such_card":            2,
+		"card_not_match":          3,
+		"invalid_want_color":      4,
+		"plus4_not_allowed":       5,
+		"already_in_room":         6,
+		"room_not_found":          7,
+		"room_full":               8,
+		"invalid_room_config":     9,
+		"reconnect_failed":        10,
+		"invalid_target":          11,
+		"must_stack_or_draw":      12,
+		"cannot_call_uno":         13,
+		"catch_uno_failed":        14,
+		"must_answer_challenge":   15,
+		"no_challenge":            16,
+		"must_play_drawn_or_pass": 17,
+		"cannot_pass":             18,
+		"replay_not_found":        19,
+		"not_replaying":           20,
+		"not_logged_in":           21,
+		"login_failed":            22,
+		"already_logged_in":       23,
+		"god_view_disabled":       24,
+		"chat_too_fast":           25,
+		"chat_too_long":           26,
+		"invalid_emote":           27,
+		"not_host":                28,
+		"not_all_ready":           29,
+		"not_waiting":             30,
+		"game_not_started":        31,
+		"stale_request":           32,
+		"chat_empty":              33,
+		"not_playing":             34,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_uno_proto_enumTypes[1].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_uno_proto_enumTypes[1]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{1}
+}
+
+// 快捷表情
+type Emote int32
+
+const (
+	Emote_emote_none      Emote = 0 // 不是表情
+	Emote_emote_hello     Emote = 1 // 你好
+	Emote_emote_good_game Emote = 2 // 打得好
+	Emote_emote_thanks    Emote = 3 // 谢谢
+	Emote_emote_oops      Emote = 4 // 哎呀
+	Emote_emote_hurry_up  Emote = 5 // 快点吧
+	Emote_emote_uno       Emote = 6 // UNO！
+)
+
+// Enum value maps for Emote.
+var (
+	Emote_name = map[int32]string{
+		0: "emote_none",
+		1: "emote_hello",
+		2: "emote_good_game",
+		3: "emote_thanks",
+		4: "emote_oops",
+		5: "emote_hurry_up",
+		6: "emote_uno",
+	}
+	Emote_value = map[string]int32{
+		"emote_none":      0,
+		"emote_hello":     1,
+		"emote_good_game": 2,
+		"emote_thanks":    3,
+		"emote_oops":      4,
+		"emote_hurry_up":  5,
+		"emote_uno":       6,
+	}
+)
+
+func (x Emote) Enum() *Emote {
+	p := new(Emote)
+	*p = x
+	return p
+}
+
+func (x Emote) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Emote) Descriptor() protoreflect.EnumDescriptor {
+	return file_uno_proto_enumTypes[2].Descriptor()
+}
+
+func (Emote) Type() protoreflect.EnumType {
+	return &file_uno_proto_enumTypes[2]
+}
+
+func (x Emote) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Emote.Descriptor instead.
+func (Emote) EnumDescriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{2}
+}
+
 // 卡牌的结构体
 type UnoCard struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        uint32                 `protobuf:"varint,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"` // 卡牌ID
+	Color         uint32                 `protobuf:"varint,2,opt,name=color,proto3" json:"color,omitempty"`                 // 1、2、3、4代表四种颜色，你爱用哪个用哪个，等价的。0代表黑牌
+	Num           uint32                 `protobuf:"varint,3,opt,name=num,proto3" json:"num,omitempty"`                     // 0-9是数字牌 10代表“跳过”牌 11代表“反向”牌 12代表“+2牌” 13代表黑牌中的变色牌 14代表黑牌中的“+4”牌
 	unknownFields protoimpl.UnknownFields
-
-	CardId uint32 `protobuf:"varint,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"` // 卡牌ID
-	Color  uint32 `protobuf:"varint,2,opt,name=color,proto3" json:"color,omitempty"`                 // 1、2、3、4代表四种颜色，你爱用哪个用哪个，等价的。0代表黑牌
-	Num    uint32 `protobuf:"varint,3,opt,name=num,proto3" json:"num,omitempty"`                     // 0-9是数字牌 10代表“跳过”牌 11代表“反向”牌 12代表“+2牌” 13代表黑牌中的变色牌 14代表黑牌中的“+4”牌
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UnoCard) Reset() {
 	*x = UnoCard{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_uno_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_uno_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *UnoCard) String() string {
@@ -48,7 +307,7 @@ func (*UnoCard) ProtoMessage() {}
 
 func (x *UnoCard) ProtoReflect() protoreflect.Message {
 	mi := &file_uno_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -84,22 +343,181 @@ func (x *UnoCard) GetNum() uint32 {
 	return 0
 }
 
-// 通知客户端：初始化游戏
-type InitToc struct {
-	state         protoimpl.MessageState
+// 玩家的身份
+type PlayerIdentity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 稳定的玩家ID，登录后确定，断线重连和下次登录时都不变。机器人为空
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                   // 显示的名字
+	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerIdentity) Reset() {
+	*x = PlayerIdentity{}
+	mi := &file_uno_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerIdentity) ProtoMessage() {}
+
+func (x *PlayerIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerIdentity.ProtoReflect.Descriptor instead.
+func (*PlayerIdentity) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PlayerIdentity) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlayerIdentity) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// 登录，之后创建或加入房间时使用登录的身份。服务器开启了验证时，必须先登录才能进行其它操作
+type LoginTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`                  // 用户名
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`                        // 登录凭证
+	AvatarId      uint32                 `protobuf:"varint,3,opt,name=avatar_id,json=avatarId,proto3" json:"avatar_id,omitempty"` // 选择的头像，用户文件中指定了头像的话以用户文件为准
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-	PlayerNum uint32 `protobuf:"varint,1,opt,name=player_num,json=playerNum,proto3" json:"player_num,omitempty"` // 玩家总人数（包括你）
+func (x *LoginTos) Reset() {
+	*x = LoginTos{}
+	mi := &file_uno_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *InitToc) Reset() {
-	*x = InitToc{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_uno_proto_msgTypes[1]
+func (x *LoginTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginTos) ProtoMessage() {}
+
+func (x *LoginTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginTos.ProtoReflect.Descriptor instead.
+func (*LoginTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LoginTos) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LoginTos) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LoginTos) GetAvatarId() uint32 {
+	if x != nil {
+		return x.AvatarId
+	}
+	return 0
+}
+
+// 通知客户端：登录成功
+type LoginToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identity      *PlayerIdentity        `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"` // 你的身份
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginToc) Reset() {
+	*x = LoginToc{}
+	mi := &file_uno_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginToc) ProtoMessage() {}
+
+func (x *LoginToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[3]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginToc.ProtoReflect.Descriptor instead.
+func (*LoginToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LoginToc) GetIdentity() *PlayerIdentity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+// 通知客户端：初始化游戏
+type InitToc struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlayerNum      uint32                 `protobuf:"varint,1,opt,name=player_num,json=playerNum,proto3" json:"player_num,omitempty"`               // 玩家总人数（包括你）
+	ReconnectToken string                 `protobuf:"bytes,2,opt,name=reconnect_token,json=reconnectToken,proto3" json:"reconnect_token,omitempty"` // 断线重连的凭证，断线后用reconnect_tos发回给服务器
+	Seed           int64                  `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`                                          // 本局的随机数种子，只有debug版本的服务器才会发送
+	Players        []*PlayerIdentity      `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`                                     // 每个座位的玩家，下标是玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InitToc) Reset() {
+	*x = InitToc{}
+	mi := &file_uno_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *InitToc) String() string {
@@ -109,8 +527,8 @@ func (x *InitToc) String() string {
 func (*InitToc) ProtoMessage() {}
 
 func (x *InitToc) ProtoReflect() protoreflect.Message {
-	mi := &file_uno_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_uno_proto_msgTypes[4]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -122,44 +540,2760 @@ func (x *InitToc) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InitToc.ProtoReflect.Descriptor instead.
 func (*InitToc) Descriptor() ([]byte, []int) {
-	return file_uno_proto_rawDescGZIP(), []int{1}
+	return file_uno_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *InitToc) GetPlayerNum() uint32 {
 	if x != nil {
-		return x.PlayerNum
+		return x.PlayerNum
+	}
+	return 0
+}
+
+func (x *InitToc) GetReconnectToken() string {
+	if x != nil {
+		return x.ReconnectToken
+	}
+	return ""
+}
+
+func (x *InitToc) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *InitToc) GetPlayers() []*PlayerIdentity {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+// 一个座位的信息
+type SeatInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	Player        *PlayerIdentity        `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`                      // 座位上的玩家
+	IsRobot       bool                   `protobuf:"varint,3,opt,name=is_robot,json=isRobot,proto3" json:"is_robot,omitempty"`    // 是否是机器人，包括替中途离开的玩家托管的机器人
+	AvatarId      uint32                 `protobuf:"varint,4,opt,name=avatar_id,json=avatarId,proto3" json:"avatar_id,omitempty"` // 头像ID，为0表示默认头像
+	Connected     bool                   `protobuf:"varint,5,opt,name=connected,proto3" json:"connected,omitempty"`               // 是否在线，断线等待重连时为false。机器人总是为true
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatInfo) Reset() {
+	*x = SeatInfo{}
+	mi := &file_uno_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatInfo) ProtoMessage() {}
+
+func (x *SeatInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatInfo.ProtoReflect.Descriptor instead.
+func (*SeatInfo) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SeatInfo) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *SeatInfo) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *SeatInfo) GetIsRobot() bool {
+	if x != nil {
+		return x.IsRobot
+	}
+	return false
+}
+
+func (x *SeatInfo) GetAvatarId() uint32 {
+	if x != nil {
+		return x.AvatarId
+	}
+	return 0
+}
+
+func (x *SeatInfo) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+// 通知客户端：所有座位的信息。开局时，以及有人入座、离开、断线、重连时都会收到
+type SeatInfoToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerNum     uint32                 `protobuf:"varint,1,opt,name=player_num,json=playerNum,proto3" json:"player_num,omitempty"` // 玩家总人数（包括你）
+	Seats         []*SeatInfo            `protobuf:"bytes,2,rep,name=seats,proto3" json:"seats,omitempty"`                           // 已经有人的座位，还没开局时玩家ID按入座的顺序计算
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatInfoToc) Reset() {
+	*x = SeatInfoToc{}
+	mi := &file_uno_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatInfoToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatInfoToc) ProtoMessage() {}
+
+func (x *SeatInfoToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatInfoToc.ProtoReflect.Descriptor instead.
+func (*SeatInfoToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SeatInfoToc) GetPlayerNum() uint32 {
+	if x != nil {
+		return x.PlayerNum
+	}
+	return 0
+}
+
+func (x *SeatInfoToc) GetSeats() []*SeatInfo {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+// 通知客户端：其他玩家摸牌
+type OtherAddHandCardToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID 你的下家是1 下下家是2 以此类推
+	Num           uint32                 `protobuf:"varint,2,opt,name=num,proto3" json:"num,omitempty"`                           // 增加的手牌数量
+	Player        *PlayerIdentity        `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"`                      // 摸牌的玩家
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OtherAddHandCardToc) Reset() {
+	*x = OtherAddHandCardToc{}
+	mi := &file_uno_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OtherAddHandCardToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OtherAddHandCardToc) ProtoMessage() {}
+
+func (x *OtherAddHandCardToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OtherAddHandCardToc.ProtoReflect.Descriptor instead.
+func (*OtherAddHandCardToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OtherAddHandCardToc) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *OtherAddHandCardToc) GetNum() uint32 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+func (x *OtherAddHandCardToc) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+// 通知客户端：你摸牌
+type DrawCardToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          []*UnoCard             `protobuf:"bytes,1,rep,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrawCardToc) Reset() {
+	*x = DrawCardToc{}
+	mi := &file_uno_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrawCardToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrawCardToc) ProtoMessage() {}
+
+func (x *DrawCardToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrawCardToc.ProtoReflect.Descriptor instead.
+func (*DrawCardToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DrawCardToc) GetCard() []*UnoCard {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+// 通知客户端：现在到谁的回合了
+type NotifyTurnToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`             // 玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	Dir           bool                   `protobuf:"varint,2,opt,name=dir,proto3" json:"dir,omitempty"`                                       // true-顺时针 false-逆时针
+	TurnDeadline  int64                  `protobuf:"varint,3,opt,name=turn_deadline,json=turnDeadline,proto3" json:"turn_deadline,omitempty"` // 本回合的截止时间（Unix毫秒时间戳，包括他剩下的备用时间），超时后服务器会替他行动。为0表示不限时
+	Player        *PlayerIdentity        `protobuf:"bytes,4,opt,name=player,proto3" json:"player,omitempty"`                                  // 轮到的玩家
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyTurnToc) Reset() {
+	*x = NotifyTurnToc{}
+	mi := &file_uno_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyTurnToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyTurnToc) ProtoMessage() {}
+
+func (x *NotifyTurnToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyTurnToc.ProtoReflect.Descriptor instead.
+func (*NotifyTurnToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NotifyTurnToc) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *NotifyTurnToc) GetDir() bool {
+	if x != nil {
+		return x.Dir
+	}
+	return false
+}
+
+func (x *NotifyTurnToc) GetTurnDeadline() int64 {
+	if x != nil {
+		return x.TurnDeadline
+	}
+	return 0
+}
+
+func (x *NotifyTurnToc) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+// 通知客户端：牌堆剩余数量（如果变多了，说明洗牌了）
+type SetDeckNumToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Num           uint32                 `protobuf:"varint,1,opt,name=num,proto3" json:"num,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDeckNumToc) Reset() {
+	*x = SetDeckNumToc{}
+	mi := &file_uno_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDeckNumToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDeckNumToc) ProtoMessage() {}
+
+func (x *SetDeckNumToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDeckNumToc.ProtoReflect.Descriptor instead.
+func (*SetDeckNumToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetDeckNumToc) GetNum() uint32 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+// 出牌
+type DiscardCardTos struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CardId         uint32                 `protobuf:"varint,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`                           // 出的牌的ID
+	WantColor      uint32                 `protobuf:"varint,2,opt,name=want_color,json=wantColor,proto3" json:"want_color,omitempty"`                  // 出黑牌时，选择想要的颜色
+	TargetPlayerId uint32                 `protobuf:"varint,3,opt,name=target_player_id,json=targetPlayerId,proto3" json:"target_player_id,omitempty"` // 开启了7-0规则时，打出7要选择和谁交换手牌 你的下家是1 下下家是2 以此类推
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DiscardCardTos) Reset() {
+	*x = DiscardCardTos{}
+	mi := &file_uno_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscardCardTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardCardTos) ProtoMessage() {}
+
+func (x *DiscardCardTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscardCardTos.ProtoReflect.Descriptor instead.
+func (*DiscardCardTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DiscardCardTos) GetCardId() uint32 {
+	if x != nil {
+		return x.CardId
+	}
+	return 0
+}
+
+func (x *DiscardCardTos) GetWantColor() uint32 {
+	if x != nil {
+		return x.WantColor
+	}
+	return 0
+}
+
+func (x *DiscardCardTos) GetTargetPlayerId() uint32 {
+	if x != nil {
+		return x.TargetPlayerId
+	}
+	return 0
+}
+
+// 通知客户端：某玩家出牌（自己出牌后，服务端也会返回这个协议）
+type DiscardCardToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	Card          *UnoCard               `protobuf:"bytes,2,opt,name=card,proto3" json:"card,omitempty"`
+	WantColor     uint32                 `protobuf:"varint,3,opt,name=want_color,json=wantColor,proto3" json:"want_color,omitempty"` // 出黑牌时，选择想要的颜色
+	Player        *PlayerIdentity        `protobuf:"bytes,4,opt,name=player,proto3" json:"player,omitempty"`                         // 出牌的玩家，翻开第一张牌时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscardCardToc) Reset() {
+	*x = DiscardCardToc{}
+	mi := &file_uno_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscardCardToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardCardToc) ProtoMessage() {}
+
+func (x *DiscardCardToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscardCardToc.ProtoReflect.Descriptor instead.
+func (*DiscardCardToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DiscardCardToc) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *DiscardCardToc) GetCard() *UnoCard {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+func (x *DiscardCardToc) GetWantColor() uint32 {
+	if x != nil {
+		return x.WantColor
+	}
+	return 0
+}
+
+func (x *DiscardCardToc) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+// 通知客户端谁赢了
+type NotifyWinToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	Player        *PlayerIdentity        `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`                      // 赢家
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyWinToc) Reset() {
+	*x = NotifyWinToc{}
+	mi := &file_uno_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyWinToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyWinToc) ProtoMessage() {}
+
+func (x *NotifyWinToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyWinToc.ProtoReflect.Descriptor instead.
+func (*NotifyWinToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NotifyWinToc) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *NotifyWinToc) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+// 投票重开，超过一半的玩家投票后重新开始一局。只能在开局之后使用
+type RestartGameTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Generation    uint32                 `protobuf:"varint,1,opt,name=generation,proto3" json:"generation,omitempty"` // 要重开的是第几局，同phase_toc。和当前不一致时说明那一局已经结束了，会被拒绝。为0表示当前这一局
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestartGameTos) Reset() {
+	*x = RestartGameTos{}
+	mi := &file_uno_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestartGameTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartGameTos) ProtoMessage() {}
+
+func (x *RestartGameTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartGameTos.ProtoReflect.Descriptor instead.
+func (*RestartGameTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RestartGameTos) GetGeneration() uint32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+// 通知客户端：房间进入了新的阶段
+type PhaseToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phase         GamePhase              `protobuf:"varint,1,opt,name=phase,proto3,enum=GamePhase" json:"phase,omitempty"`
+	Generation    uint32                 `protobuf:"varint,2,opt,name=generation,proto3" json:"generation,omitempty"` // 开过的局数，每次发牌时加一，包括投票重开的局。用来区分过期的请求
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhaseToc) Reset() {
+	*x = PhaseToc{}
+	mi := &file_uno_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhaseToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseToc) ProtoMessage() {}
+
+func (x *PhaseToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseToc.ProtoReflect.Descriptor instead.
+func (*PhaseToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PhaseToc) GetPhase() GamePhase {
+	if x != nil {
+		return x.Phase
+	}
+	return GamePhase_phase_waiting
+}
+
+func (x *PhaseToc) GetGeneration() uint32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+// 通知客户端：有人投票重开
+type RestartVoteToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 投票的玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	Player        *PlayerIdentity        `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`                      // 投票的玩家
+	Votes         uint32                 `protobuf:"varint,3,opt,name=votes,proto3" json:"votes,omitempty"`                       // 已经投票的人数
+	Needed        uint32                 `protobuf:"varint,4,opt,name=needed,proto3" json:"needed,omitempty"`                     // 重开需要的票数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestartVoteToc) Reset() {
+	*x = RestartVoteToc{}
+	mi := &file_uno_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestartVoteToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestartVoteToc) ProtoMessage() {}
+
+func (x *RestartVoteToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestartVoteToc.ProtoReflect.Descriptor instead.
+func (*RestartVoteToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RestartVoteToc) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *RestartVoteToc) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *RestartVoteToc) GetVotes() uint32 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+func (x *RestartVoteToc) GetNeeded() uint32 {
+	if x != nil {
+		return x.Needed
+	}
+	return 0
+}
+
+// 准备或者取消准备，只能在等待开局时使用。座位坐满、所有玩家都准备好后自动开局
+type ReadyTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ready         bool                   `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadyTos) Reset() {
+	*x = ReadyTos{}
+	mi := &file_uno_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadyTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadyTos) ProtoMessage() {}
+
+func (x *ReadyTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadyTos.ProtoReflect.Descriptor instead.
+func (*ReadyTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReadyTos) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+// 通知客户端：等待开局时的准备状态。入座、离开、准备或者取消准备时都会收到，玩家ID按入座的顺序计算
+type ReadyStateToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HostId        uint32                 `protobuf:"varint,1,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`                  // 房主的玩家ID，房主是最早入座的玩家，可以用start_game_tos提前开局
+	ReadyIds      []uint32               `protobuf:"varint,2,rep,packed,name=ready_ids,json=readyIds,proto3" json:"ready_ids,omitempty"`     // 已经准备好的玩家ID，机器人总是准备好的
+	PlayerNum     uint32                 `protobuf:"varint,3,opt,name=player_num,json=playerNum,proto3" json:"player_num,omitempty"`         // 总人数
+	SeatedNum     uint32                 `protobuf:"varint,4,opt,name=seated_num,json=seatedNum,proto3" json:"seated_num,omitempty"`         // 已经入座的人数（包括机器人）
+	Host          *PlayerIdentity        `protobuf:"bytes,5,opt,name=host,proto3" json:"host,omitempty"`                                     // 房主
+	ReadyPlayers  []*PlayerIdentity      `protobuf:"bytes,6,rep,name=ready_players,json=readyPlayers,proto3" json:"ready_players,omitempty"` // 已经准备好的玩家，和ready_ids一一对应
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadyStateToc) Reset() {
+	*x = ReadyStateToc{}
+	mi := &file_uno_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadyStateToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadyStateToc) ProtoMessage() {}
+
+func (x *ReadyStateToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadyStateToc.ProtoReflect.Descriptor instead.
+func (*ReadyStateToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReadyStateToc) GetHostId() uint32 {
+	if x != nil {
+		return x.HostId
+	}
+	return 0
+}
+
+func (x *ReadyStateToc) GetReadyIds() []uint32 {
+	if x != nil {
+		return x.ReadyIds
+	}
+	return nil
+}
+
+func (x *ReadyStateToc) GetPlayerNum() uint32 {
+	if x != nil {
+		return x.PlayerNum
+	}
+	return 0
+}
+
+func (x *ReadyStateToc) GetSeatedNum() uint32 {
+	if x != nil {
+		return x.SeatedNum
+	}
+	return 0
+}
+
+func (x *ReadyStateToc) GetHost() *PlayerIdentity {
+	if x != nil {
+		return x.Host
+	}
+	return nil
+}
+
+func (x *ReadyStateToc) GetReadyPlayers() []*PlayerIdentity {
+	if x != nil {
+		return x.ReadyPlayers
+	}
+	return nil
+}
+
+// 房主提前开局，空着的座位由机器人补上。其他玩家都要先准备好
+type StartGameTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartGameTos) Reset() {
+	*x = StartGameTos{}
+	mi := &file_uno_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartGameTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartGameTos) ProtoMessage() {}
+
+func (x *StartGameTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartGameTos.ProtoReflect.Descriptor instead.
+func (*StartGameTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{19}
+}
+
+// 房间信息
+type RoomInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RoomId          uint32                 `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`                           // 房间ID
+	PlayerNum       uint32                 `protobuf:"varint,2,opt,name=player_num,json=playerNum,proto3" json:"player_num,omitempty"`                  // 总人数（包括机器人）
+	RobotNum        uint32                 `protobuf:"varint,3,opt,name=robot_num,json=robotNum,proto3" json:"robot_num,omitempty"`                     // 机器人人数
+	HumanNum        uint32                 `protobuf:"varint,4,opt,name=human_num,json=humanNum,proto3" json:"human_num,omitempty"`                     // 已加入的玩家人数
+	Playing         bool                   `protobuf:"varint,5,opt,name=playing,proto3" json:"playing,omitempty"`                                       // 是否正在游戏中
+	Rules           *Rules                 `protobuf:"bytes,6,opt,name=rules,proto3" json:"rules,omitempty"`                                            // 房规
+	RobotStrategies []string               `protobuf:"bytes,7,rep,name=robot_strategies,json=robotStrategies,proto3" json:"robot_strategies,omitempty"` // 每个机器人座位的策略
+	SpectatorNum    uint32                 `protobuf:"varint,8,opt,name=spectator_num,json=spectatorNum,proto3" json:"spectator_num,omitempty"`         // 观战人数
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RoomInfo) Reset() {
+	*x = RoomInfo{}
+	mi := &file_uno_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomInfo) ProtoMessage() {}
+
+func (x *RoomInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomInfo.ProtoReflect.Descriptor instead.
+func (*RoomInfo) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RoomInfo) GetRoomId() uint32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetPlayerNum() uint32 {
+	if x != nil {
+		return x.PlayerNum
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetRobotNum() uint32 {
+	if x != nil {
+		return x.RobotNum
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetHumanNum() uint32 {
+	if x != nil {
+		return x.HumanNum
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetPlaying() bool {
+	if x != nil {
+		return x.Playing
+	}
+	return false
+}
+
+func (x *RoomInfo) GetRules() *Rules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *RoomInfo) GetRobotStrategies() []string {
+	if x != nil {
+		return x.RobotStrategies
+	}
+	return nil
+}
+
+func (x *RoomInfo) GetSpectatorNum() uint32 {
+	if x != nil {
+		return x.SpectatorNum
+	}
+	return 0
+}
+
+// 通知客户端：房间列表（在大厅中时，房间有变化就会收到）
+type RoomListToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rooms         []*RoomInfo            `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomListToc) Reset() {
+	*x = RoomListToc{}
+	mi := &file_uno_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomListToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomListToc) ProtoMessage() {}
+
+func (x *RoomListToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomListToc.ProtoReflect.Descriptor instead.
+func (*RoomListToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RoomListToc) GetRooms() []*RoomInfo {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+// 房规
+type Rules struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	JumpIn            bool                   `protobuf:"varint,1,opt,name=jump_in,json=jumpIn,proto3" json:"jump_in,omitempty"`                                    // 抢牌：手里有和最后打出的牌完全相同的牌时，不在自己的回合也可以打出，然后从他开始继续
+	SevenZero         bool                   `protobuf:"varint,2,opt,name=seven_zero,json=sevenZero,proto3" json:"seven_zero,omitempty"`                           // 7-0：打出7时和指定的玩家交换手牌，打出0时所有人把手牌传给下一个玩家
+	DrawUntilPlayable bool                   `protobuf:"varint,3,opt,name=draw_until_playable,json=drawUntilPlayable,proto3" json:"draw_until_playable,omitempty"` // 摸牌时一直摸到能打出的牌为止
+	ForcePlay         bool                   `protobuf:"varint,4,opt,name=force_play,json=forcePlay,proto3" json:"force_play,omitempty"`                           // 摸到的牌能打出时必须打出，不能选择不出
+	StackPlus2        bool                   `protobuf:"varint,5,opt,name=stack_plus2,json=stackPlus2,proto3" json:"stack_plus2,omitempty"`                        // 被+2时可以再打出+2，让下家累积摸牌
+	StackPlus4        bool                   `protobuf:"varint,6,opt,name=stack_plus4,json=stackPlus4,proto3" json:"stack_plus4,omitempty"`                        // 被+2或+4时可以再打出+4，让下家累积摸牌
+	ChallengePlus4    bool                   `protobuf:"varint,7,opt,name=challenge_plus4,json=challengePlus4,proto3" json:"challenge_plus4,omitempty"`            // +4质疑（官方规则）：+4任何时候都可以打出，但下家可以质疑，质疑成功则出牌者摸4张，失败则质疑者摸6张
+	TargetScore       uint32                 `protobuf:"varint,8,opt,name=target_score,json=targetScore,proto3" json:"target_score,omitempty"`                     // 比赛的目标分数，有人的总分达到后比赛结束，为0表示不计分
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Rules) Reset() {
+	*x = Rules{}
+	mi := &file_uno_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rules) ProtoMessage() {}
+
+func (x *Rules) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rules.ProtoReflect.Descriptor instead.
+func (*Rules) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Rules) GetJumpIn() bool {
+	if x != nil {
+		return x.JumpIn
+	}
+	return false
+}
+
+func (x *Rules) GetSevenZero() bool {
+	if x != nil {
+		return x.SevenZero
+	}
+	return false
+}
+
+func (x *Rules) GetDrawUntilPlayable() bool {
+	if x != nil {
+		return x.DrawUntilPlayable
+	}
+	return false
+}
+
+func (x *Rules) GetForcePlay() bool {
+	if x != nil {
+		return x.ForcePlay
+	}
+	return false
+}
+
+func (x *Rules) GetStackPlus2() bool {
+	if x != nil {
+		return x.StackPlus2
+	}
+	return false
+}
+
+func (x *Rules) GetStackPlus4() bool {
+	if x != nil {
+		return x.StackPlus4
+	}
+	return false
+}
+
+func (x *Rules) GetChallengePlus4() bool {
+	if x != nil {
+		return x.ChallengePlus4
+	}
+	return false
+}
+
+func (x *Rules) GetTargetScore() uint32 {
+	if x != nil {
+		return x.TargetScore
+	}
+	return 0
+}
+
+// 创建房间，创建者自动加入该房间
+type CreateRoomTos struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PlayerNum       uint32                 `protobuf:"varint,1,opt,name=player_num,json=playerNum,proto3" json:"player_num,omitempty"`                  // 总人数（包括机器人），填0则使用服务器的默认配置
+	RobotNum        uint32                 `protobuf:"varint,2,opt,name=robot_num,json=robotNum,proto3" json:"robot_num,omitempty"`                     // 机器人人数
+	Rules           *Rules                 `protobuf:"bytes,3,opt,name=rules,proto3" json:"rules,omitempty"`                                            // 房规，不填则使用服务器的默认配置
+	RobotStrategies []string               `protobuf:"bytes,4,rep,name=robot_strategies,json=robotStrategies,proto3" json:"robot_strategies,omitempty"` // 每个机器人座位的策略：easy-简单 normal-普通 hard-困难 mcts-蒙特卡洛树搜索，不填的座位使用服务器的默认配置
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateRoomTos) Reset() {
+	*x = CreateRoomTos{}
+	mi := &file_uno_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomTos) ProtoMessage() {}
+
+func (x *CreateRoomTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomTos.ProtoReflect.Descriptor instead.
+func (*CreateRoomTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateRoomTos) GetPlayerNum() uint32 {
+	if x != nil {
+		return x.PlayerNum
+	}
+	return 0
+}
+
+func (x *CreateRoomTos) GetRobotNum() uint32 {
+	if x != nil {
+		return x.RobotNum
+	}
+	return 0
+}
+
+func (x *CreateRoomTos) GetRules() *Rules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *CreateRoomTos) GetRobotStrategies() []string {
+	if x != nil {
+		return x.RobotStrategies
+	}
+	return nil
+}
+
+// 加入房间
+type JoinRoomTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        uint32                 `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRoomTos) Reset() {
+	*x = JoinRoomTos{}
+	mi := &file_uno_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRoomTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRoomTos) ProtoMessage() {}
+
+func (x *JoinRoomTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRoomTos.ProtoReflect.Descriptor instead.
+func (*JoinRoomTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *JoinRoomTos) GetRoomId() uint32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+// 通知客户端：你加入了房间
+type JoinRoomToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        uint32                 `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRoomToc) Reset() {
+	*x = JoinRoomToc{}
+	mi := &file_uno_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRoomToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRoomToc) ProtoMessage() {}
+
+func (x *JoinRoomToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRoomToc.ProtoReflect.Descriptor instead.
+func (*JoinRoomToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *JoinRoomToc) GetRoomId() uint32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+// 离开房间，回到大厅。观战时用这个协议退出观战
+type LeaveRoomTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveRoomTos) Reset() {
+	*x = LeaveRoomTos{}
+	mi := &file_uno_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveRoomTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveRoomTos) ProtoMessage() {}
+
+func (x *LeaveRoomTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveRoomTos.ProtoReflect.Descriptor instead.
+func (*LeaveRoomTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{26}
+}
+
+// 观战，只能在大厅中使用。观战者能看到所有公开的信息，但看不到任何人的手牌
+type SpectateTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        uint32                 `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	GodView       bool                   `protobuf:"varint,2,opt,name=god_view,json=godView,proto3" json:"god_view,omitempty"` // 是否开启上帝视角（供解说使用），开启后会延迟一段时间收到所有人的手牌
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpectateTos) Reset() {
+	*x = SpectateTos{}
+	mi := &file_uno_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpectateTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectateTos) ProtoMessage() {}
+
+func (x *SpectateTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectateTos.ProtoReflect.Descriptor instead.
+func (*SpectateTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SpectateTos) GetRoomId() uint32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SpectateTos) GetGodView() bool {
+	if x != nil {
+		return x.GodView
+	}
+	return false
+}
+
+// 通知客户端：你开始观战了，之后会收到init_toc、game_state_toc、seat_info_toc以及各种公开的消息。
+// 观战者以0号座位的视角接收消息，所以消息中的玩家ID就是座位号
+type SpectateToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        uint32                 `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	GodViewDelay  uint32                 `protobuf:"varint,2,opt,name=god_view_delay,json=godViewDelay,proto3" json:"god_view_delay,omitempty"` // 上帝视角的延迟秒数，没有开启上帝视角则为0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpectateToc) Reset() {
+	*x = SpectateToc{}
+	mi := &file_uno_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpectateToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectateToc) ProtoMessage() {}
+
+func (x *SpectateToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectateToc.ProtoReflect.Descriptor instead.
+func (*SpectateToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SpectateToc) GetRoomId() uint32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *SpectateToc) GetGodViewDelay() uint32 {
+	if x != nil {
+		return x.GodViewDelay
+	}
+	return 0
+}
+
+// 上帝视角中一个玩家的手牌
+type GodViewHand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 座位号
+	Cards         []*UnoCard             `protobuf:"bytes,2,rep,name=cards,proto3" json:"cards,omitempty"`
+	Player        *PlayerIdentity        `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"` // 座位上的玩家
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GodViewHand) Reset() {
+	*x = GodViewHand{}
+	mi := &file_uno_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GodViewHand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GodViewHand) ProtoMessage() {}
+
+func (x *GodViewHand) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GodViewHand.ProtoReflect.Descriptor instead.
+func (*GodViewHand) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GodViewHand) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *GodViewHand) GetCards() []*UnoCard {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *GodViewHand) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+// 通知观战者：上帝视角中所有人的手牌。每次轮到某人时记录一次，延迟god_view_delay秒后发送
+type GodViewToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          int64                  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"` // 记录这些手牌的时间（Unix毫秒时间戳）
+	Hands         []*GodViewHand         `protobuf:"bytes,2,rep,name=hands,proto3" json:"hands,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GodViewToc) Reset() {
+	*x = GodViewToc{}
+	mi := &file_uno_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GodViewToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GodViewToc) ProtoMessage() {}
+
+func (x *GodViewToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GodViewToc.ProtoReflect.Descriptor instead.
+func (*GodViewToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GodViewToc) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *GodViewToc) GetHands() []*GodViewHand {
+	if x != nil {
+		return x.Hands
+	}
+	return nil
+}
+
+// 断线重连，成功后会依次收到join_room_toc、init_toc、game_state_toc
+type ReconnectTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // init_toc中收到的reconnect_token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconnectTos) Reset() {
+	*x = ReconnectTos{}
+	mi := &file_uno_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconnectTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconnectTos) ProtoMessage() {}
+
+func (x *ReconnectTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconnectTos.ProtoReflect.Descriptor instead.
+func (*ReconnectTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReconnectTos) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// 通知客户端：完整的局面。收到后应以此为准，覆盖本地记录的所有状态
+type GameStateToc struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PlayerNum        uint32                 `protobuf:"varint,1,opt,name=player_num,json=playerNum,proto3" json:"player_num,omitempty"`                       // 玩家总人数（包括你）
+	HandCard         []*UnoCard             `protobuf:"bytes,2,rep,name=hand_card,json=handCard,proto3" json:"hand_card,omitempty"`                           // 你的手牌
+	HandCardNum      []uint32               `protobuf:"varint,3,rep,packed,name=hand_card_num,json=handCardNum,proto3" json:"hand_card_num,omitempty"`        // 每个玩家的手牌数量，下标是玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	LastCard         *UnoCard               `protobuf:"bytes,4,opt,name=last_card,json=lastCard,proto3" json:"last_card,omitempty"`                           // 最后打出的牌
+	WantColor        uint32                 `protobuf:"varint,5,opt,name=want_color,json=wantColor,proto3" json:"want_color,omitempty"`                       // 当前要出的颜色，0代表任意颜色都可以
+	Dir              bool                   `protobuf:"varint,6,opt,name=dir,proto3" json:"dir,omitempty"`                                                    // true-顺时针 false-逆时针
+	WhoseTurn        uint32                 `protobuf:"varint,7,opt,name=whose_turn,json=whoseTurn,proto3" json:"whose_turn,omitempty"`                       // 现在是谁的回合，玩家ID同上
+	DeckNum          uint32                 `protobuf:"varint,8,opt,name=deck_num,json=deckNum,proto3" json:"deck_num,omitempty"`                             // 牌堆剩余数量
+	Playing          bool                   `protobuf:"varint,9,opt,name=playing,proto3" json:"playing,omitempty"`                                            // 是否正在游戏中，为false时说明这一局还没开始或者已经结束了
+	PendingDraw      uint32                 `protobuf:"varint,10,opt,name=pending_draw,json=pendingDraw,proto3" json:"pending_draw,omitempty"`                // 当前累积的罚摸牌数
+	ChallengePending bool                   `protobuf:"varint,11,opt,name=challenge_pending,json=challengePending,proto3" json:"challenge_pending,omitempty"` // 你现在是否要决定质疑上家打出的+4
+	DrawnCard        *UnoCard               `protobuf:"bytes,12,opt,name=drawn_card,json=drawnCard,proto3" json:"drawn_card,omitempty"`                       // 你刚摸到的能打出的牌，正在等你决定是否打出
+	Score            []uint32               `protobuf:"varint,13,rep,packed,name=score,proto3" json:"score,omitempty"`                                        // 本场比赛每个玩家的总分，下标是玩家ID
+	Round            uint32                 `protobuf:"varint,14,opt,name=round,proto3" json:"round,omitempty"`                                               // 本场比赛的第几局
+	TurnDeadline     int64                  `protobuf:"varint,15,opt,name=turn_deadline,json=turnDeadline,proto3" json:"turn_deadline,omitempty"`             // 本回合的截止时间，同notify_turn_toc
+	Players          []*PlayerIdentity      `protobuf:"bytes,16,rep,name=players,proto3" json:"players,omitempty"`                                            // 每个座位的玩家，下标是玩家ID
+	Phase            GamePhase              `protobuf:"varint,17,opt,name=phase,proto3,enum=GamePhase" json:"phase,omitempty"`                                // 房间所处的阶段
+	Generation       uint32                 `protobuf:"varint,18,opt,name=generation,proto3" json:"generation,omitempty"`                                     // 开过的局数，同phase_toc
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GameStateToc) Reset() {
+	*x = GameStateToc{}
+	mi := &file_uno_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameStateToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameStateToc) ProtoMessage() {}
+
+func (x *GameStateToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameStateToc.ProtoReflect.Descriptor instead.
+func (*GameStateToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GameStateToc) GetPlayerNum() uint32 {
+	if x != nil {
+		return x.PlayerNum
+	}
+	return 0
+}
+
+func (x *GameStateToc) GetHandCard() []*UnoCard {
+	if x != nil {
+		return x.HandCard
+	}
+	return nil
+}
+
+func (x *GameStateToc) GetHandCardNum() []uint32 {
+	if x != nil {
+		return x.HandCardNum
+	}
+	return nil
+}
+
+func (x *GameStateToc) GetLastCard() *UnoCard {
+	if x != nil {
+		return x.LastCard
+	}
+	return nil
+}
+
+func (x *GameStateToc) GetWantColor() uint32 {
+	if x != nil {
+		return x.WantColor
+	}
+	return 0
+}
+
+func (x *GameStateToc) GetDir() bool {
+	if x != nil {
+		return x.Dir
+	}
+	return false
+}
+
+func (x *GameStateToc) GetWhoseTurn() uint32 {
+	if x != nil {
+		return x.WhoseTurn
+	}
+	return 0
+}
+
+func (x *GameStateToc) GetDeckNum() uint32 {
+	if x != nil {
+		return x.DeckNum
+	}
+	return 0
+}
+
+func (x *GameStateToc) GetPlaying() bool {
+	if x != nil {
+		return x.Playing
+	}
+	return false
+}
+
+func (x *GameStateToc) GetPendingDraw() uint32 {
+	if x != nil {
+		return x.PendingDraw
+	}
+	return 0
+}
+
+func (x *GameStateToc) GetChallengePending() bool {
+	if x != nil {
+		return x.ChallengePending
+	}
+	return false
+}
+
+func (x *GameStateToc) GetDrawnCard() *UnoCard {
+	if x != nil {
+		return x.DrawnCard
+	}
+	return nil
+}
+
+func (x *GameStateToc) GetScore() []uint32 {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+func (x *GameStateToc) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *GameStateToc) GetTurnDeadline() int64 {
+	if x != nil {
+		return x.TurnDeadline
+	}
+	return 0
+}
+
+func (x *GameStateToc) GetPlayers() []*PlayerIdentity {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *GameStateToc) GetPhase() GamePhase {
+	if x != nil {
+		return x.Phase
+	}
+	return GamePhase_phase_waiting
+}
+
+func (x *GameStateToc) GetGeneration() uint32 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+// 请求完整的局面，服务器会回复game_state_toc
+type RequestStateTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestStateTos) Reset() {
+	*x = RequestStateTos{}
+	mi := &file_uno_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestStateTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestStateTos) ProtoMessage() {}
+
+func (x *RequestStateTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestStateTos.ProtoReflect.Descriptor instead.
+func (*RequestStateTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{33}
+}
+
+// 通知客户端：你的操作被拒绝了
+type ErrorToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          ErrorCode              `protobuf:"varint,1,opt,name=code,proto3,enum=ErrorCode" json:"code,omitempty"`
+	CardId        uint32                 `protobuf:"varint,2,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"` // 如果是出牌被拒绝，这是你想出的那张牌的ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorToc) Reset() {
+	*x = ErrorToc{}
+	mi := &file_uno_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorToc) ProtoMessage() {}
+
+func (x *ErrorToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorToc.ProtoReflect.Descriptor instead.
+func (*ErrorToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ErrorToc) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_success
+}
+
+func (x *ErrorToc) GetCardId() uint32 {
+	if x != nil {
+		return x.CardId
+	}
+	return 0
+}
+
+// 通知客户端：累积的罚摸牌数有变化（开启了+2/+4叠加的房规时）
+type PendingDrawToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 现在要应对罚摸牌的玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	Num           uint32                 `protobuf:"varint,2,opt,name=num,proto3" json:"num,omitempty"`                           // 累积的罚摸牌数，为0表示累积结束了
+	Player        *PlayerIdentity        `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"`                      // 要应对罚摸牌的玩家
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingDrawToc) Reset() {
+	*x = PendingDrawToc{}
+	mi := &file_uno_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingDrawToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingDrawToc) ProtoMessage() {}
+
+func (x *PendingDrawToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingDrawToc.ProtoReflect.Descriptor instead.
+func (*PendingDrawToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PendingDrawToc) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *PendingDrawToc) GetNum() uint32 {
+	if x != nil {
+		return x.Num
+	}
+	return 0
+}
+
+func (x *PendingDrawToc) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+// 喊UNO。手牌只剩一张，或者即将打出倒数第二张牌时可以喊
+type CallUnoTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallUnoTos) Reset() {
+	*x = CallUnoTos{}
+	mi := &file_uno_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallUnoTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallUnoTos) ProtoMessage() {}
+
+func (x *CallUnoTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallUnoTos.ProtoReflect.Descriptor instead.
+func (*CallUnoTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{36}
+}
+
+// 通知客户端：某玩家喊了UNO
+type UnoCalledToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	Player        *PlayerIdentity        `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`                      // 喊UNO的玩家
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnoCalledToc) Reset() {
+	*x = UnoCalledToc{}
+	mi := &file_uno_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnoCalledToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnoCalledToc) ProtoMessage() {}
+
+func (x *UnoCalledToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnoCalledToc.ProtoReflect.Descriptor instead.
+func (*UnoCalledToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UnoCalledToc) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *UnoCalledToc) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+// 抓忘记喊UNO的玩家。在他的下家行动之前抓到他的话，他要罚摸2张牌
+type CatchUnoTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 要抓的玩家ID 你的下家是1 下下家是2 以此类推
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatchUnoTos) Reset() {
+	*x = CatchUnoTos{}
+	mi := &file_uno_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatchUnoTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatchUnoTos) ProtoMessage() {}
+
+func (x *CatchUnoTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatchUnoTos.ProtoReflect.Descriptor instead.
+func (*CatchUnoTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CatchUnoTos) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+// 通知客户端：某玩家忘记喊UNO被抓到了
+type UnoCaughtToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`    // 被抓的玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	CatcherId     uint32                 `protobuf:"varint,2,opt,name=catcher_id,json=catcherId,proto3" json:"catcher_id,omitempty"` // 抓他的玩家ID
+	Player        *PlayerIdentity        `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"`                         // 被抓的玩家
+	Catcher       *PlayerIdentity        `protobuf:"bytes,4,opt,name=catcher,proto3" json:"catcher,omitempty"`                       // 抓他的玩家
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnoCaughtToc) Reset() {
+	*x = UnoCaughtToc{}
+	mi := &file_uno_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnoCaughtToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnoCaughtToc) ProtoMessage() {}
+
+func (x *UnoCaughtToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnoCaughtToc.ProtoReflect.Descriptor instead.
+func (*UnoCaughtToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UnoCaughtToc) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *UnoCaughtToc) GetCatcherId() uint32 {
+	if x != nil {
+		return x.CatcherId
+	}
+	return 0
+}
+
+func (x *UnoCaughtToc) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *UnoCaughtToc) GetCatcher() *PlayerIdentity {
+	if x != nil {
+		return x.Catcher
+	}
+	return nil
+}
+
+// 质疑上家打出的+4（开启了+4质疑的房规时）。不质疑的话，也可以直接用discard_card_tos摸牌
+type ChallengePlus4Tos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Challenge     bool                   `protobuf:"varint,1,opt,name=challenge,proto3" json:"challenge,omitempty"` // true-质疑 false-不质疑，直接摸4张牌并跳过回合
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChallengePlus4Tos) Reset() {
+	*x = ChallengePlus4Tos{}
+	mi := &file_uno_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChallengePlus4Tos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengePlus4Tos) ProtoMessage() {}
+
+func (x *ChallengePlus4Tos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengePlus4Tos.ProtoReflect.Descriptor instead.
+func (*ChallengePlus4Tos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ChallengePlus4Tos) GetChallenge() bool {
+	if x != nil {
+		return x.Challenge
+	}
+	return false
+}
+
+// 通知客户端：质疑+4的结果
+type ChallengePlus4Toc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 质疑者的玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	TargetId      uint32                 `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"` // 打出+4的玩家ID
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`                   // true-质疑成功，打出+4的玩家摸4张牌 false-质疑失败，质疑者摸6张牌并跳过回合
+	Player        *PlayerIdentity        `protobuf:"bytes,4,opt,name=player,proto3" json:"player,omitempty"`                      // 质疑者
+	Target        *PlayerIdentity        `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`                      // 打出+4的玩家
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChallengePlus4Toc) Reset() {
+	*x = ChallengePlus4Toc{}
+	mi := &file_uno_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChallengePlus4Toc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengePlus4Toc) ProtoMessage() {}
+
+func (x *ChallengePlus4Toc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengePlus4Toc.ProtoReflect.Descriptor instead.
+func (*ChallengePlus4Toc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ChallengePlus4Toc) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *ChallengePlus4Toc) GetTargetId() uint32 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *ChallengePlus4Toc) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ChallengePlus4Toc) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *ChallengePlus4Toc) GetTarget() *PlayerIdentity {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+// 通知客户端：质疑+4时被质疑的玩家的手牌，只有质疑者能收到
+type RevealHandToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID 你的下家是1 下下家是2 以此类推
+	Cards         []*UnoCard             `protobuf:"bytes,2,rep,name=cards,proto3" json:"cards,omitempty"`
+	Player        *PlayerIdentity        `protobuf:"bytes,3,opt,name=player,proto3" json:"player,omitempty"` // 被质疑的玩家
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevealHandToc) Reset() {
+	*x = RevealHandToc{}
+	mi := &file_uno_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevealHandToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevealHandToc) ProtoMessage() {}
+
+func (x *RevealHandToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevealHandToc.ProtoReflect.Descriptor instead.
+func (*RevealHandToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RevealHandToc) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *RevealHandToc) GetCards() []*UnoCard {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *RevealHandToc) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+// 通知客户端：你摸到的牌。如果能打出，接下来可以用discard_card_tos打出这张牌，或者用pass_tos不出
+type DrawnCardToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *UnoCard               `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	Playable      bool                   `protobuf:"varint,2,opt,name=playable,proto3" json:"playable,omitempty"` // 是否能打出，为false时会直接轮到下一个玩家
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrawnCardToc) Reset() {
+	*x = DrawnCardToc{}
+	mi := &file_uno_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrawnCardToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrawnCardToc) ProtoMessage() {}
+
+func (x *DrawnCardToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrawnCardToc.ProtoReflect.Descriptor instead.
+func (*DrawnCardToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DrawnCardToc) GetCard() *UnoCard {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+func (x *DrawnCardToc) GetPlayable() bool {
+	if x != nil {
+		return x.Playable
+	}
+	return false
+}
+
+// 摸牌后不出刚摸到的牌，结束回合
+type PassTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PassTos) Reset() {
+	*x = PassTos{}
+	mi := &file_uno_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PassTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PassTos) ProtoMessage() {}
+
+func (x *PassTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PassTos.ProtoReflect.Descriptor instead.
+func (*PassTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{44}
+}
+
+// 一个玩家在一局中的结算
+type PlayerRoundResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	HandCard      []*UnoCard             `protobuf:"bytes,2,rep,name=hand_card,json=handCard,proto3" json:"hand_card,omitempty"`  // 剩下的手牌
+	Points        uint32                 `protobuf:"varint,3,opt,name=points,proto3" json:"points,omitempty"`                     // 剩下的手牌的分数：数字牌按数字计分，功能牌20分，黑牌50分
+	Score         uint32                 `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`                       // 本场比赛的总分
+	Player        *PlayerIdentity        `protobuf:"bytes,5,opt,name=player,proto3" json:"player,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerRoundResult) Reset() {
+	*x = PlayerRoundResult{}
+	mi := &file_uno_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerRoundResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerRoundResult) ProtoMessage() {}
+
+func (x *PlayerRoundResult) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerRoundResult.ProtoReflect.Descriptor instead.
+func (*PlayerRoundResult) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PlayerRoundResult) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *PlayerRoundResult) GetHandCard() []*UnoCard {
+	if x != nil {
+		return x.HandCard
+	}
+	return nil
+}
+
+func (x *PlayerRoundResult) GetPoints() uint32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *PlayerRoundResult) GetScore() uint32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PlayerRoundResult) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+// 通知客户端：一局结束的结算。赢家得到其他所有玩家剩下的手牌的分数
+type RoundResultToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WinnerId      uint32                 `protobuf:"varint,1,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"` // 赢家的玩家ID
+	Round         uint32                 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`                       // 本场比赛的第几局
+	Points        uint32                 `protobuf:"varint,3,opt,name=points,proto3" json:"points,omitempty"`                     // 赢家本局得分
+	Results       []*PlayerRoundResult   `protobuf:"bytes,4,rep,name=results,proto3" json:"results,omitempty"`
+	Winner        *PlayerIdentity        `protobuf:"bytes,5,opt,name=winner,proto3" json:"winner,omitempty"` // 赢家
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoundResultToc) Reset() {
+	*x = RoundResultToc{}
+	mi := &file_uno_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoundResultToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoundResultToc) ProtoMessage() {}
+
+func (x *RoundResultToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoundResultToc.ProtoReflect.Descriptor instead.
+func (*RoundResultToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RoundResultToc) GetWinnerId() uint32 {
+	if x != nil {
+		return x.WinnerId
+	}
+	return 0
+}
+
+func (x *RoundResultToc) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *RoundResultToc) GetPoints() uint32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *RoundResultToc) GetResults() []*PlayerRoundResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *RoundResultToc) GetWinner() *PlayerIdentity {
+	if x != nil {
+		return x.Winner
+	}
+	return nil
+}
+
+// 通知客户端：比赛结束，有人的总分达到了目标分数
+type MatchResultToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WinnerId      uint32                 `protobuf:"varint,1,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`          // 赢家的玩家ID
+	TargetScore   uint32                 `protobuf:"varint,2,opt,name=target_score,json=targetScore,proto3" json:"target_score,omitempty"` // 目标分数
+	Score         []uint32               `protobuf:"varint,3,rep,packed,name=score,proto3" json:"score,omitempty"`                         // 每个玩家的总分，下标是玩家ID
+	Winner        *PlayerIdentity        `protobuf:"bytes,4,opt,name=winner,proto3" json:"winner,omitempty"`                               // 赢家
+	Players       []*PlayerIdentity      `protobuf:"bytes,5,rep,name=players,proto3" json:"players,omitempty"`                             // 每个座位的玩家，下标是玩家ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchResultToc) Reset() {
+	*x = MatchResultToc{}
+	mi := &file_uno_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchResultToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchResultToc) ProtoMessage() {}
+
+func (x *MatchResultToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchResultToc.ProtoReflect.Descriptor instead.
+func (*MatchResultToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *MatchResultToc) GetWinnerId() uint32 {
+	if x != nil {
+		return x.WinnerId
+	}
+	return 0
+}
+
+func (x *MatchResultToc) GetTargetScore() uint32 {
+	if x != nil {
+		return x.TargetScore
+	}
+	return 0
+}
+
+func (x *MatchResultToc) GetScore() []uint32 {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+func (x *MatchResultToc) GetWinner() *PlayerIdentity {
+	if x != nil {
+		return x.Winner
+	}
+	return nil
+}
+
+func (x *MatchResultToc) GetPlayers() []*PlayerIdentity {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+// 请求回放列表，只能在大厅中使用
+type ReplayListTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayListTos) Reset() {
+	*x = ReplayListTos{}
+	mi := &file_uno_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayListTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayListTos) ProtoMessage() {}
+
+func (x *ReplayListTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayListTos.ProtoReflect.Descriptor instead.
+func (*ReplayListTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{48}
+}
+
+// 通知客户端：所有可以回放的对局，按时间从早到晚排列
+type ReplayListToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReplayIds     []string               `protobuf:"bytes,1,rep,name=replay_ids,json=replayIds,proto3" json:"replay_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayListToc) Reset() {
+	*x = ReplayListToc{}
+	mi := &file_uno_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayListToc) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayListToc) ProtoMessage() {}
+
+func (x *ReplayListToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayListToc.ProtoReflect.Descriptor instead.
+func (*ReplayListToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ReplayListToc) GetReplayIds() []string {
+	if x != nil {
+		return x.ReplayIds
 	}
-	return 0
+	return nil
 }
 
-// 通知客户端：其他玩家摸牌
-type OtherAddHandCardToc struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+// 回放一局游戏，只能在大厅中使用。加入房间或者再次请求回放时，正在进行的回放会停止
+type ReplayTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReplayId      string                 `protobuf:"bytes,1,opt,name=replay_id,json=replayId,proto3" json:"replay_id,omitempty"`
+	Speed         float64                `protobuf:"fixed64,2,opt,name=speed,proto3" json:"speed,omitempty"` // 播放速度，1为原速，2为两倍速，填0则为原速
 	unknownFields protoimpl.UnknownFields
-
-	PlayerId uint32 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID 你的下家是1 下下家是2 以此类推
-	Num      uint32 `protobuf:"varint,2,opt,name=num,proto3" json:"num,omitempty"`                           // 增加的手牌数量
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OtherAddHandCardToc) Reset() {
-	*x = OtherAddHandCardToc{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_uno_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *ReplayTos) Reset() {
+	*x = ReplayTos{}
+	mi := &file_uno_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *OtherAddHandCardToc) String() string {
+func (x *ReplayTos) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OtherAddHandCardToc) ProtoMessage() {}
+func (*ReplayTos) ProtoMessage() {}
 
-func (x *OtherAddHandCardToc) ProtoReflect() protoreflect.Message {
-	mi := &file_uno_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *ReplayTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[50]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -169,52 +3303,49 @@ func (x *OtherAddHandCardToc) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OtherAddHandCardToc.ProtoReflect.Descriptor instead.
-func (*OtherAddHandCardToc) Descriptor() ([]byte, []int) {
-	return file_uno_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ReplayTos.ProtoReflect.Descriptor instead.
+func (*ReplayTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *OtherAddHandCardToc) GetPlayerId() uint32 {
+func (x *ReplayTos) GetReplayId() string {
 	if x != nil {
-		return x.PlayerId
+		return x.ReplayId
 	}
-	return 0
+	return ""
 }
 
-func (x *OtherAddHandCardToc) GetNum() uint32 {
+func (x *ReplayTos) GetSpeed() float64 {
 	if x != nil {
-		return x.Num
+		return x.Speed
 	}
 	return 0
 }
 
-// 通知客户端：你摸牌
-type DrawCardToc struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+// 调整正在进行的回放的速度
+type ReplaySpeedTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Speed         float64                `protobuf:"fixed64,1,opt,name=speed,proto3" json:"speed,omitempty"` // 播放速度，为0表示暂停
 	unknownFields protoimpl.UnknownFields
-
-	Card []*UnoCard `protobuf:"bytes,1,rep,name=card,proto3" json:"card,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DrawCardToc) Reset() {
-	*x = DrawCardToc{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_uno_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *ReplaySpeedTos) Reset() {
+	*x = ReplaySpeedTos{}
+	mi := &file_uno_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *DrawCardToc) String() string {
+func (x *ReplaySpeedTos) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DrawCardToc) ProtoMessage() {}
+func (*ReplaySpeedTos) ProtoMessage() {}
 
-func (x *DrawCardToc) ProtoReflect() protoreflect.Message {
-	mi := &file_uno_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *ReplaySpeedTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[51]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -224,46 +3355,47 @@ func (x *DrawCardToc) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DrawCardToc.ProtoReflect.Descriptor instead.
-func (*DrawCardToc) Descriptor() ([]byte, []int) {
-	return file_uno_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ReplaySpeedTos.ProtoReflect.Descriptor instead.
+func (*ReplaySpeedTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *DrawCardToc) GetCard() []*UnoCard {
+func (x *ReplaySpeedTos) GetSpeed() float64 {
 	if x != nil {
-		return x.Card
+		return x.Speed
 	}
-	return nil
+	return 0
 }
 
-// 通知客户端：现在到谁的回合了
-type NotifyTurnToc struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+// 通知客户端：回放开始，这一局的基本信息
+type ReplayStartToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReplayId      string                 `protobuf:"bytes,1,opt,name=replay_id,json=replayId,proto3" json:"replay_id,omitempty"`
+	Seed          int64                  `protobuf:"varint,2,opt,name=seed,proto3" json:"seed,omitempty"`                            // 随机数种子
+	PlayerNum     uint32                 `protobuf:"varint,3,opt,name=player_num,json=playerNum,proto3" json:"player_num,omitempty"` // 人数
+	Players       []string               `protobuf:"bytes,4,rep,name=players,proto3" json:"players,omitempty"`                       // 每个座位是玩家还是机器人：玩家为human，机器人为策略的名字
+	Rules         *Rules                 `protobuf:"bytes,5,opt,name=rules,proto3" json:"rules,omitempty"`                           // 房规
+	Identities    []*PlayerIdentity      `protobuf:"bytes,6,rep,name=identities,proto3" json:"identities,omitempty"`                 // 每个座位的玩家的身份，下标是座位号
 	unknownFields protoimpl.UnknownFields
-
-	PlayerId uint32 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID 你是0 你的下家是1 下下家是2 以此类推
-	Dir      bool   `protobuf:"varint,2,opt,name=dir,proto3" json:"dir,omitempty"`                           // true-顺时针 false-逆时针
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NotifyTurnToc) Reset() {
-	*x = NotifyTurnToc{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_uno_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *ReplayStartToc) Reset() {
+	*x = ReplayStartToc{}
+	mi := &file_uno_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *NotifyTurnToc) String() string {
+func (x *ReplayStartToc) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotifyTurnToc) ProtoMessage() {}
+func (*ReplayStartToc) ProtoMessage() {}
 
-func (x *NotifyTurnToc) ProtoReflect() protoreflect.Message {
-	mi := &file_uno_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *ReplayStartToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[52]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -273,52 +3405,86 @@ func (x *NotifyTurnToc) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotifyTurnToc.ProtoReflect.Descriptor instead.
-func (*NotifyTurnToc) Descriptor() ([]byte, []int) {
-	return file_uno_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ReplayStartToc.ProtoReflect.Descriptor instead.
+func (*ReplayStartToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *NotifyTurnToc) GetPlayerId() uint32 {
+func (x *ReplayStartToc) GetReplayId() string {
 	if x != nil {
-		return x.PlayerId
+		return x.ReplayId
+	}
+	return ""
+}
+
+func (x *ReplayStartToc) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
 	}
 	return 0
 }
 
-func (x *NotifyTurnToc) GetDir() bool {
+func (x *ReplayStartToc) GetPlayerNum() uint32 {
 	if x != nil {
-		return x.Dir
+		return x.PlayerNum
 	}
-	return false
+	return 0
 }
 
-// 通知客户端：牌堆剩余数量（如果变多了，说明洗牌了）
-type SetDeckNumToc struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *ReplayStartToc) GetPlayers() []string {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
 
-	Num uint32 `protobuf:"varint,1,opt,name=num,proto3" json:"num,omitempty"`
+func (x *ReplayStartToc) GetRules() *Rules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
 }
 
-func (x *SetDeckNumToc) Reset() {
-	*x = SetDeckNumToc{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_uno_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *ReplayStartToc) GetIdentities() []*PlayerIdentity {
+	if x != nil {
+		return x.Identities
 	}
+	return nil
 }
 
-func (x *SetDeckNumToc) String() string {
+// 通知客户端：回放中的一个事件。回放中的座位号都是绝对位置，可以看到所有人的牌
+type ReplayEventToc struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Time           int64                  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`                                             // 距离这一局开始的毫秒数
+	Type           string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                                              // 事件类型：deal-发牌 flip-翻开第一张牌 draw-摸牌 discard-出牌 turn-轮到某人 pass-摸牌后不出 uno_call-喊UNO uno_catch-抓UNO challenge-质疑+4 swap-交换手牌 rotate-所有人传手牌 round_over-一局结束 abort-中止 chat-聊天 emote-快捷表情
+	PlayerId       uint32                 `protobuf:"varint,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`                     // 发生事件的玩家
+	TargetPlayerId uint32                 `protobuf:"varint,4,opt,name=target_player_id,json=targetPlayerId,proto3" json:"target_player_id,omitempty"` // 事件涉及的另一个玩家
+	Cards          []*UnoCard             `protobuf:"bytes,5,rep,name=cards,proto3" json:"cards,omitempty"`                                            // 发的牌、摸的牌或者打出的牌
+	WantColor      uint32                 `protobuf:"varint,6,opt,name=want_color,json=wantColor,proto3" json:"want_color,omitempty"`                  // 打出黑牌时选择的颜色
+	Dir            bool                   `protobuf:"varint,7,opt,name=dir,proto3" json:"dir,omitempty"`                                               // 轮到某人时的方向
+	Count          int32                  `protobuf:"varint,8,opt,name=count,proto3" json:"count,omitempty"`                                           // 一局结束时赢家得到的分数
+	Success        bool                   `protobuf:"varint,9,opt,name=success,proto3" json:"success,omitempty"`                                       // 质疑+4是否成功
+	Text           string                 `protobuf:"bytes,10,opt,name=text,proto3" json:"text,omitempty"`                                             // 聊天消息，或者快捷表情的名字
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReplayEventToc) Reset() {
+	*x = ReplayEventToc{}
+	mi := &file_uno_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayEventToc) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetDeckNumToc) ProtoMessage() {}
+func (*ReplayEventToc) ProtoMessage() {}
 
-func (x *SetDeckNumToc) ProtoReflect() protoreflect.Message {
-	mi := &file_uno_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *ReplayEventToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[53]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -328,103 +3494,105 @@ func (x *SetDeckNumToc) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetDeckNumToc.ProtoReflect.Descriptor instead.
-func (*SetDeckNumToc) Descriptor() ([]byte, []int) {
-	return file_uno_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ReplayEventToc.ProtoReflect.Descriptor instead.
+func (*ReplayEventToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *SetDeckNumToc) GetNum() uint32 {
+func (x *ReplayEventToc) GetTime() int64 {
 	if x != nil {
-		return x.Num
+		return x.Time
 	}
 	return 0
 }
 
-// 出牌
-type DiscardCardTos struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	CardId    uint32 `protobuf:"varint,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`          // 出的牌的ID
-	WantColor uint32 `protobuf:"varint,2,opt,name=want_color,json=wantColor,proto3" json:"want_color,omitempty"` // 出黑牌时，选择想要的颜色
+func (x *ReplayEventToc) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
 
-func (x *DiscardCardTos) Reset() {
-	*x = DiscardCardTos{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_uno_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *ReplayEventToc) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
 	}
+	return 0
 }
 
-func (x *DiscardCardTos) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ReplayEventToc) GetTargetPlayerId() uint32 {
+	if x != nil {
+		return x.TargetPlayerId
+	}
+	return 0
 }
 
-func (*DiscardCardTos) ProtoMessage() {}
+func (x *ReplayEventToc) GetCards() []*UnoCard {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
 
-func (x *DiscardCardTos) ProtoReflect() protoreflect.Message {
-	mi := &file_uno_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *ReplayEventToc) GetWantColor() uint32 {
+	if x != nil {
+		return x.WantColor
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use DiscardCardTos.ProtoReflect.Descriptor instead.
-func (*DiscardCardTos) Descriptor() ([]byte, []int) {
-	return file_uno_proto_rawDescGZIP(), []int{6}
+func (x *ReplayEventToc) GetDir() bool {
+	if x != nil {
+		return x.Dir
+	}
+	return false
 }
 
-func (x *DiscardCardTos) GetCardId() uint32 {
+func (x *ReplayEventToc) GetCount() int32 {
 	if x != nil {
-		return x.CardId
+		return x.Count
 	}
 	return 0
 }
 
-func (x *DiscardCardTos) GetWantColor() uint32 {
+func (x *ReplayEventToc) GetSuccess() bool {
 	if x != nil {
-		return x.WantColor
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-// 通知客户端：某玩家出牌（自己出牌后，服务端也会返回这个协议）
-type DiscardCardToc struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *ReplayEventToc) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
 
-	PlayerId  uint32   `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID 你是0 你的下家是1 下下家是2 以此类推
-	Card      *UnoCard `protobuf:"bytes,2,opt,name=card,proto3" json:"card,omitempty"`
-	WantColor uint32   `protobuf:"varint,3,opt,name=want_color,json=wantColor,proto3" json:"want_color,omitempty"` // 出黑牌时，选择想要的颜色
+// 通知客户端：回放结束
+type ReplayEndToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReplayId      string                 `protobuf:"bytes,1,opt,name=replay_id,json=replayId,proto3" json:"replay_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DiscardCardToc) Reset() {
-	*x = DiscardCardToc{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_uno_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *ReplayEndToc) Reset() {
+	*x = ReplayEndToc{}
+	mi := &file_uno_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *DiscardCardToc) String() string {
+func (x *ReplayEndToc) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DiscardCardToc) ProtoMessage() {}
+func (*ReplayEndToc) ProtoMessage() {}
 
-func (x *DiscardCardToc) ProtoReflect() protoreflect.Message {
-	mi := &file_uno_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *ReplayEndToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[54]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -434,59 +3602,87 @@ func (x *DiscardCardToc) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DiscardCardToc.ProtoReflect.Descriptor instead.
-func (*DiscardCardToc) Descriptor() ([]byte, []int) {
-	return file_uno_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ReplayEndToc.ProtoReflect.Descriptor instead.
+func (*ReplayEndToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *DiscardCardToc) GetPlayerId() uint32 {
+func (x *ReplayEndToc) GetReplayId() string {
 	if x != nil {
-		return x.PlayerId
+		return x.ReplayId
 	}
-	return 0
+	return ""
 }
 
-func (x *DiscardCardToc) GetCard() *UnoCard {
+// 发送聊天消息，只能在房间中入座后使用
+type ChatTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatTos) Reset() {
+	*x = ChatTos{}
+	mi := &file_uno_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatTos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatTos) ProtoMessage() {}
+
+func (x *ChatTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[55]
 	if x != nil {
-		return x.Card
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *DiscardCardToc) GetWantColor() uint32 {
+// Deprecated: Use ChatTos.ProtoReflect.Descriptor instead.
+func (*ChatTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ChatTos) GetText() string {
 	if x != nil {
-		return x.WantColor
+		return x.Text
 	}
-	return 0
+	return ""
 }
 
-// 通知客户端谁赢了
-type NotifyWinToc struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+// 发送快捷表情，只能在房间中入座后使用
+type EmoteTos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Emote         Emote                  `protobuf:"varint,1,opt,name=emote,proto3,enum=Emote" json:"emote,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	PlayerId uint32 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NotifyWinToc) Reset() {
-	*x = NotifyWinToc{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_uno_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *EmoteTos) Reset() {
+	*x = EmoteTos{}
+	mi := &file_uno_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *NotifyWinToc) String() string {
+func (x *EmoteTos) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotifyWinToc) ProtoMessage() {}
+func (*EmoteTos) ProtoMessage() {}
 
-func (x *NotifyWinToc) ProtoReflect() protoreflect.Message {
-	mi := &file_uno_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *EmoteTos) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[56]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -496,43 +3692,45 @@ func (x *NotifyWinToc) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotifyWinToc.ProtoReflect.Descriptor instead.
-func (*NotifyWinToc) Descriptor() ([]byte, []int) {
-	return file_uno_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use EmoteTos.ProtoReflect.Descriptor instead.
+func (*EmoteTos) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *NotifyWinToc) GetPlayerId() uint32 {
+func (x *EmoteTos) GetEmote() Emote {
 	if x != nil {
-		return x.PlayerId
+		return x.Emote
 	}
-	return 0
+	return Emote_emote_none
 }
 
-// 重开
-type RestartGameTos struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+// 通知客户端：某玩家发了聊天消息或者快捷表情。房间中的玩家和观战者都会收到
+type ChatToc struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      uint32                 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // 玩家ID 你是0 你的下家是1 下下家是2 以此类推
+	Player        *PlayerIdentity        `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`                      // 发言的玩家
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`                          // 聊天消息，敏感词已经被替换掉了。发快捷表情时为空
+	Emote         Emote                  `protobuf:"varint,4,opt,name=emote,proto3,enum=Emote" json:"emote,omitempty"`            // 快捷表情，发聊天消息时为emote_none
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RestartGameTos) Reset() {
-	*x = RestartGameTos{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_uno_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x *ChatToc) Reset() {
+	*x = ChatToc{}
+	mi := &file_uno_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestartGameTos) String() string {
+func (x *ChatToc) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestartGameTos) ProtoMessage() {}
+func (*ChatToc) ProtoMessage() {}
 
-func (x *RestartGameTos) ProtoReflect() protoreflect.Message {
-	mi := &file_uno_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *ChatToc) ProtoReflect() protoreflect.Message {
+	mi := &file_uno_proto_msgTypes[57]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -542,89 +3740,490 @@ func (x *RestartGameTos) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestartGameTos.ProtoReflect.Descriptor instead.
-func (*RestartGameTos) Descriptor() ([]byte, []int) {
-	return file_uno_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ChatToc.ProtoReflect.Descriptor instead.
+func (*ChatToc) Descriptor() ([]byte, []int) {
+	return file_uno_proto_rawDescGZIP(), []int{57}
 }
 
-var File_uno_proto protoreflect.FileDescriptor
+func (x *ChatToc) GetPlayerId() uint32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
 
-var file_uno_proto_rawDesc = []byte{
-	0x0a, 0x09, 0x75, 0x6e, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4b, 0x0a, 0x08, 0x75,
-	0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72, 0x64, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x61, 0x72, 0x64, 0x49, 0x64,
-	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x22, 0x29, 0x0a, 0x08, 0x69, 0x6e, 0x69, 0x74,
-	0x5f, 0x74, 0x6f, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6e,
-	0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x4e, 0x75, 0x6d, 0x22, 0x48, 0x0a, 0x17, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64,
-	0x5f, 0x68, 0x61, 0x6e, 0x64, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x74, 0x6f, 0x63, 0x12, 0x1b,
-	0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6e,
-	0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x22, 0x2e, 0x0a,
-	0x0d, 0x64, 0x72, 0x61, 0x77, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x74, 0x6f, 0x63, 0x12, 0x1d,
-	0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x75,
-	0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x52, 0x04, 0x63, 0x61, 0x72, 0x64, 0x22, 0x40, 0x0a,
-	0x0f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x74, 0x6f, 0x63,
-	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x10, 0x0a,
-	0x03, 0x64, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x64, 0x69, 0x72, 0x22,
-	0x24, 0x0a, 0x10, 0x73, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x5f,
-	0x74, 0x6f, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x03, 0x6e, 0x75, 0x6d, 0x22, 0x4a, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64,
-	0x5f, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x74, 0x6f, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x72,
-	0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x61, 0x72, 0x64,
-	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x61, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x77, 0x61, 0x6e, 0x74, 0x43, 0x6f, 0x6c, 0x6f,
-	0x72, 0x22, 0x6d, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x63, 0x61, 0x72,
-	0x64, 0x5f, 0x74, 0x6f, 0x63, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x04, 0x63, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x09, 0x2e, 0x75, 0x6e, 0x6f, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x52, 0x04, 0x63, 0x61, 0x72,
-	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x61, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x77, 0x61, 0x6e, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
-	0x22, 0x2d, 0x0a, 0x0e, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x77, 0x69, 0x6e, 0x5f, 0x74,
-	0x6f, 0x63, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22,
-	0x12, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x5f,
-	0x74, 0x6f, 0x73, 0x42, 0x10, 0x5a, 0x0e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x3b, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *ChatToc) GetPlayer() *PlayerIdentity {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *ChatToc) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ChatToc) GetEmote() Emote {
+	if x != nil {
+		return x.Emote
+	}
+	return Emote_emote_none
 }
 
+var File_uno_proto protoreflect.FileDescriptor
+
+const file_uno_proto_rawDesc = "" +
+	"\n" +
+	"\tuno.proto\"K\n" +
+	"\buno_card\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\rR\x06cardId\x12\x14\n" +
+	"\x05color\x18\x02 \x01(\rR\x05color\x12\x10\n" +
+	"\x03num\x18\x03 \x01(\rR\x03num\">\n" +
+	"\x0fplayer_identity\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"Z\n" +
+	"\tlogin_tos\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x1b\n" +
+	"\tavatar_id\x18\x03 \x01(\rR\bavatarId\"9\n" +
+	"\tlogin_toc\x12,\n" +
+	"\bidentity\x18\x01 \x01(\v2\x10.player_identityR\bidentity\"\x92\x01\n" +
+	"\binit_toc\x12\x1d\n" +
+	"\n" +
+	"player_num\x18\x01 \x01(\rR\tplayerNum\x12'\n" +
+	"\x0freconnect_token\x18\x02 \x01(\tR\x0ereconnectToken\x12\x12\n" +
+	"\x04seed\x18\x03 \x01(\x03R\x04seed\x12*\n" +
+	"\aplayers\x18\x04 \x03(\v2\x10.player_identityR\aplayers\"\xa8\x01\n" +
+	"\tseat_info\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12(\n" +
+	"\x06player\x18\x02 \x01(\v2\x10.player_identityR\x06player\x12\x19\n" +
+	"\bis_robot\x18\x03 \x01(\bR\aisRobot\x12\x1b\n" +
+	"\tavatar_id\x18\x04 \x01(\rR\bavatarId\x12\x1c\n" +
+	"\tconnected\x18\x05 \x01(\bR\tconnected\"P\n" +
+	"\rseat_info_toc\x12\x1d\n" +
+	"\n" +
+	"player_num\x18\x01 \x01(\rR\tplayerNum\x12 \n" +
+	"\x05seats\x18\x02 \x03(\v2\n" +
+	".seat_infoR\x05seats\"r\n" +
+	"\x17other_add_hand_card_toc\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12\x10\n" +
+	"\x03num\x18\x02 \x01(\rR\x03num\x12(\n" +
+	"\x06player\x18\x03 \x01(\v2\x10.player_identityR\x06player\".\n" +
+	"\rdraw_card_toc\x12\x1d\n" +
+	"\x04card\x18\x01 \x03(\v2\t.uno_cardR\x04card\"\x8f\x01\n" +
+	"\x0fnotify_turn_toc\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12\x10\n" +
+	"\x03dir\x18\x02 \x01(\bR\x03dir\x12#\n" +
+	"\rturn_deadline\x18\x03 \x01(\x03R\fturnDeadline\x12(\n" +
+	"\x06player\x18\x04 \x01(\v2\x10.player_identityR\x06player\"$\n" +
+	"\x10set_deck_num_toc\x12\x10\n" +
+	"\x03num\x18\x01 \x01(\rR\x03num\"t\n" +
+	"\x10discard_card_tos\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\rR\x06cardId\x12\x1d\n" +
+	"\n" +
+	"want_color\x18\x02 \x01(\rR\twantColor\x12(\n" +
+	"\x10target_player_id\x18\x03 \x01(\rR\x0etargetPlayerId\"\x97\x01\n" +
+	"\x10discard_card_toc\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12\x1d\n" +
+	"\x04card\x18\x02 \x01(\v2\t.uno_cardR\x04card\x12\x1d\n" +
+	"\n" +
+	"want_color\x18\x03 \x01(\rR\twantColor\x12(\n" +
+	"\x06player\x18\x04 \x01(\v2\x10.player_identityR\x06player\"W\n" +
+	"\x0enotify_win_toc\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12(\n" +
+	"\x06player\x18\x02 \x01(\v2\x10.player_identityR\x06player\"2\n" +
+	"\x10restart_game_tos\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x01 \x01(\rR\n" +
+	"generation\"N\n" +
+	"\tphase_toc\x12!\n" +
+	"\x05phase\x18\x01 \x01(\x0e2\v.game_phaseR\x05phase\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x02 \x01(\rR\n" +
+	"generation\"\x87\x01\n" +
+	"\x10restart_vote_toc\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12(\n" +
+	"\x06player\x18\x02 \x01(\v2\x10.player_identityR\x06player\x12\x14\n" +
+	"\x05votes\x18\x03 \x01(\rR\x05votes\x12\x16\n" +
+	"\x06needed\x18\x04 \x01(\rR\x06needed\"!\n" +
+	"\tready_tos\x12\x14\n" +
+	"\x05ready\x18\x01 \x01(\bR\x05ready\"\xe2\x01\n" +
+	"\x0fready_state_toc\x12\x17\n" +
+	"\ahost_id\x18\x01 \x01(\rR\x06hostId\x12\x1b\n" +
+	"\tready_ids\x18\x02 \x03(\rR\breadyIds\x12\x1d\n" +
+	"\n" +
+	"player_num\x18\x03 \x01(\rR\tplayerNum\x12\x1d\n" +
+	"\n" +
+	"seated_num\x18\x04 \x01(\rR\tseatedNum\x12$\n" +
+	"\x04host\x18\x05 \x01(\v2\x10.player_identityR\x04host\x125\n" +
+	"\rready_players\x18\x06 \x03(\v2\x10.player_identityR\freadyPlayers\"\x10\n" +
+	"\x0estart_game_tos\"\x85\x02\n" +
+	"\troom_info\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\rR\x06roomId\x12\x1d\n" +
+	"\n" +
+	"player_num\x18\x02 \x01(\rR\tplayerNum\x12\x1b\n" +
+	"\trobot_num\x18\x03 \x01(\rR\brobotNum\x12\x1b\n" +
+	"\thuman_num\x18\x04 \x01(\rR\bhumanNum\x12\x18\n" +
+	"\aplaying\x18\x05 \x01(\bR\aplaying\x12\x1c\n" +
+	"\x05rules\x18\x06 \x01(\v2\x06.rulesR\x05rules\x12)\n" +
+	"\x10robot_strategies\x18\a \x03(\tR\x0frobotStrategies\x12#\n" +
+	"\rspectator_num\x18\b \x01(\rR\fspectatorNum\"1\n" +
+	"\rroom_list_toc\x12 \n" +
+	"\x05rooms\x18\x01 \x03(\v2\n" +
+	".room_infoR\x05rooms\"\x9c\x02\n" +
+	"\x05rules\x12\x17\n" +
+	"\ajump_in\x18\x01 \x01(\bR\x06jumpIn\x12\x1d\n" +
+	"\n" +
+	"seven_zero\x18\x02 \x01(\bR\tsevenZero\x12.\n" +
+	"\x13draw_until_playable\x18\x03 \x01(\bR\x11drawUntilPlayable\x12\x1d\n" +
+	"\n" +
+	"force_play\x18\x04 \x01(\bR\tforcePlay\x12\x1f\n" +
+	"\vstack_plus2\x18\x05 \x01(\bR\n" +
+	"stackPlus2\x12\x1f\n" +
+	"\vstack_plus4\x18\x06 \x01(\bR\n" +
+	"stackPlus4\x12'\n" +
+	"\x0fchallenge_plus4\x18\a \x01(\bR\x0echallengePlus4\x12!\n" +
+	"\ftarget_score\x18\b \x01(\rR\vtargetScore\"\x96\x01\n" +
+	"\x0fcreate_room_tos\x12\x1d\n" +
+	"\n" +
+	"player_num\x18\x01 \x01(\rR\tplayerNum\x12\x1b\n" +
+	"\trobot_num\x18\x02 \x01(\rR\brobotNum\x12\x1c\n" +
+	"\x05rules\x18\x03 \x01(\v2\x06.rulesR\x05rules\x12)\n" +
+	"\x10robot_strategies\x18\x04 \x03(\tR\x0frobotStrategies\"(\n" +
+	"\rjoin_room_tos\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\rR\x06roomId\"(\n" +
+	"\rjoin_room_toc\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\rR\x06roomId\"\x10\n" +
+	"\x0eleave_room_tos\"B\n" +
+	"\fspectate_tos\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\rR\x06roomId\x12\x19\n" +
+	"\bgod_view\x18\x02 \x01(\bR\agodView\"M\n" +
+	"\fspectate_toc\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\rR\x06roomId\x12$\n" +
+	"\x0egod_view_delay\x18\x02 \x01(\rR\fgodViewDelay\"w\n" +
+	"\rgod_view_hand\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12\x1f\n" +
+	"\x05cards\x18\x02 \x03(\v2\t.uno_cardR\x05cards\x12(\n" +
+	"\x06player\x18\x03 \x01(\v2\x10.player_identityR\x06player\"H\n" +
+	"\fgod_view_toc\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12$\n" +
+	"\x05hands\x18\x02 \x03(\v2\x0e.god_view_handR\x05hands\"%\n" +
+	"\rreconnect_tos\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\xe2\x04\n" +
+	"\x0egame_state_toc\x12\x1d\n" +
+	"\n" +
+	"player_num\x18\x01 \x01(\rR\tplayerNum\x12&\n" +
+	"\thand_card\x18\x02 \x03(\v2\t.uno_cardR\bhandCard\x12\"\n" +
+	"\rhand_card_num\x18\x03 \x03(\rR\vhandCardNum\x12&\n" +
+	"\tlast_card\x18\x04 \x01(\v2\t.uno_cardR\blastCard\x12\x1d\n" +
+	"\n" +
+	"want_color\x18\x05 \x01(\rR\twantColor\x12\x10\n" +
+	"\x03dir\x18\x06 \x01(\bR\x03dir\x12\x1d\n" +
+	"\n" +
+	"whose_turn\x18\a \x01(\rR\twhoseTurn\x12\x19\n" +
+	"\bdeck_num\x18\b \x01(\rR\adeckNum\x12\x18\n" +
+	"\aplaying\x18\t \x01(\bR\aplaying\x12!\n" +
+	"\fpending_draw\x18\n" +
+	" \x01(\rR\vpendingDraw\x12+\n" +
+	"\x11challenge_pending\x18\v \x01(\bR\x10challengePending\x12(\n" +
+	"\n" +
+	"drawn_card\x18\f \x01(\v2\t.uno_cardR\tdrawnCard\x12\x14\n" +
+	"\x05score\x18\r \x03(\rR\x05score\x12\x14\n" +
+	"\x05round\x18\x0e \x01(\rR\x05round\x12#\n" +
+	"\rturn_deadline\x18\x0f \x01(\x03R\fturnDeadline\x12*\n" +
+	"\aplayers\x18\x10 \x03(\v2\x10.player_identityR\aplayers\x12!\n" +
+	"\x05phase\x18\x11 \x01(\x0e2\v.game_phaseR\x05phase\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x12 \x01(\rR\n" +
+	"generation\"\x13\n" +
+	"\x11request_state_tos\"E\n" +
+	"\terror_toc\x12\x1f\n" +
+	"\x04code\x18\x01 \x01(\x0e2\v.error_codeR\x04code\x12\x17\n" +
+	"\acard_id\x18\x02 \x01(\rR\x06cardId\"k\n" +
+	"\x10pending_draw_toc\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12\x10\n" +
+	"\x03num\x18\x02 \x01(\rR\x03num\x12(\n" +
+	"\x06player\x18\x03 \x01(\v2\x10.player_identityR\x06player\"\x0e\n" +
+	"\fcall_uno_tos\"W\n" +
+	"\x0euno_called_toc\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12(\n" +
+	"\x06player\x18\x02 \x01(\v2\x10.player_identityR\x06player\",\n" +
+	"\rcatch_uno_tos\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\"\xa2\x01\n" +
+	"\x0euno_caught_toc\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12\x1d\n" +
+	"\n" +
+	"catcher_id\x18\x02 \x01(\rR\tcatcherId\x12(\n" +
+	"\x06player\x18\x03 \x01(\v2\x10.player_identityR\x06player\x12*\n" +
+	"\acatcher\x18\x04 \x01(\v2\x10.player_identityR\acatcher\"3\n" +
+	"\x13challenge_plus4_tos\x12\x1c\n" +
+	"\tchallenge\x18\x01 \x01(\bR\tchallenge\"\xbd\x01\n" +
+	"\x13challenge_plus4_toc\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\rR\btargetId\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12(\n" +
+	"\x06player\x18\x04 \x01(\v2\x10.player_identityR\x06player\x12(\n" +
+	"\x06target\x18\x05 \x01(\v2\x10.player_identityR\x06target\"y\n" +
+	"\x0freveal_hand_toc\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12\x1f\n" +
+	"\x05cards\x18\x02 \x03(\v2\t.uno_cardR\x05cards\x12(\n" +
+	"\x06player\x18\x03 \x01(\v2\x10.player_identityR\x06player\"K\n" +
+	"\x0edrawn_card_toc\x12\x1d\n" +
+	"\x04card\x18\x01 \x01(\v2\t.uno_cardR\x04card\x12\x1a\n" +
+	"\bplayable\x18\x02 \x01(\bR\bplayable\"\n" +
+	"\n" +
+	"\bpass_tos\"\xb2\x01\n" +
+	"\x13player_round_result\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12&\n" +
+	"\thand_card\x18\x02 \x03(\v2\t.uno_cardR\bhandCard\x12\x16\n" +
+	"\x06points\x18\x03 \x01(\rR\x06points\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\rR\x05score\x12(\n" +
+	"\x06player\x18\x05 \x01(\v2\x10.player_identityR\x06player\"\xb7\x01\n" +
+	"\x10round_result_toc\x12\x1b\n" +
+	"\twinner_id\x18\x01 \x01(\rR\bwinnerId\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\rR\x05round\x12\x16\n" +
+	"\x06points\x18\x03 \x01(\rR\x06points\x12.\n" +
+	"\aresults\x18\x04 \x03(\v2\x14.player_round_resultR\aresults\x12(\n" +
+	"\x06winner\x18\x05 \x01(\v2\x10.player_identityR\x06winner\"\xbe\x01\n" +
+	"\x10match_result_toc\x12\x1b\n" +
+	"\twinner_id\x18\x01 \x01(\rR\bwinnerId\x12!\n" +
+	"\ftarget_score\x18\x02 \x01(\rR\vtargetScore\x12\x14\n" +
+	"\x05score\x18\x03 \x03(\rR\x05score\x12(\n" +
+	"\x06winner\x18\x04 \x01(\v2\x10.player_identityR\x06winner\x12*\n" +
+	"\aplayers\x18\x05 \x03(\v2\x10.player_identityR\aplayers\"\x11\n" +
+	"\x0freplay_list_tos\"0\n" +
+	"\x0freplay_list_toc\x12\x1d\n" +
+	"\n" +
+	"replay_ids\x18\x01 \x03(\tR\treplayIds\"?\n" +
+	"\n" +
+	"replay_tos\x12\x1b\n" +
+	"\treplay_id\x18\x01 \x01(\tR\breplayId\x12\x14\n" +
+	"\x05speed\x18\x02 \x01(\x01R\x05speed\"(\n" +
+	"\x10replay_speed_tos\x12\x14\n" +
+	"\x05speed\x18\x01 \x01(\x01R\x05speed\"\xcc\x01\n" +
+	"\x10replay_start_toc\x12\x1b\n" +
+	"\treplay_id\x18\x01 \x01(\tR\breplayId\x12\x12\n" +
+	"\x04seed\x18\x02 \x01(\x03R\x04seed\x12\x1d\n" +
+	"\n" +
+	"player_num\x18\x03 \x01(\rR\tplayerNum\x12\x18\n" +
+	"\aplayers\x18\x04 \x03(\tR\aplayers\x12\x1c\n" +
+	"\x05rules\x18\x05 \x01(\v2\x06.rulesR\x05rules\x120\n" +
+	"\n" +
+	"identities\x18\x06 \x03(\v2\x10.player_identityR\n" +
+	"identities\"\x97\x02\n" +
+	"\x10replay_event_toc\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1b\n" +
+	"\tplayer_id\x18\x03 \x01(\rR\bplayerId\x12(\n" +
+	"\x10target_player_id\x18\x04 \x01(\rR\x0etargetPlayerId\x12\x1f\n" +
+	"\x05cards\x18\x05 \x03(\v2\t.uno_cardR\x05cards\x12\x1d\n" +
+	"\n" +
+	"want_color\x18\x06 \x01(\rR\twantColor\x12\x10\n" +
+	"\x03dir\x18\a \x01(\bR\x03dir\x12\x14\n" +
+	"\x05count\x18\b \x01(\x05R\x05count\x12\x18\n" +
+	"\asuccess\x18\t \x01(\bR\asuccess\x12\x12\n" +
+	"\x04text\x18\n" +
+	" \x01(\tR\x04text\"-\n" +
+	"\x0ereplay_end_toc\x12\x1b\n" +
+	"\treplay_id\x18\x01 \x01(\tR\breplayId\"\x1e\n" +
+	"\bchat_tos\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\")\n" +
+	"\temote_tos\x12\x1c\n" +
+	"\x05emote\x18\x01 \x01(\x0e2\x06.emoteR\x05emote\"\x83\x01\n" +
+	"\bchat_toc\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\rR\bplayerId\x12(\n" +
+	"\x06player\x18\x02 \x01(\v2\x10.player_identityR\x06player\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\x1c\n" +
+	"\x05emote\x18\x04 \x01(\x0e2\x06.emoteR\x05emote*[\n" +
+	"\n" +
+	"game_phase\x12\x11\n" +
+	"\rphase_waiting\x10\x00\x12\x11\n" +
+	"\rphase_dealing\x10\x01\x12\x11\n" +
+	"\rphase_playing\x10\x02\x12\x14\n" +
+	"\x10phase_round_over\x10\x03*\xcb\x05\n" +
+	"\n" +
+	"error_code\x12\v\n" +
+	"\asuccess\x10\x00\x12\x11\n" +
+	"\rnot_your_turn\x10\x01\x12\x10\n" +
+	"\fno_such_card\x10\x02\x12\x12\n" +
+	"\x0ecard_not_match\x10\x03\x12\x16\n" +
+	"\x12invalid_want_color\x10\x04\x12\x15\n" +
+	"\x11plus4_not_allowed\x10\x05\x12\x13\n" +
+	"\x0falready_in_room\x10\x06\x12\x12\n" +
+	"\x0eroom_not_found\x10\a\x12\r\n" +
+	"\troom_full\x10\b\x12\x17\n" +
+	"\x13invalid_room_config\x10\t\x12\x14\n" +
+	"\x10reconnect_failed\x10\n" +
+	"\x12\x12\n" +
+	"\x0einvalid_target\x10\v\x12\x16\n" +
+	"\x12must_stack_or_draw\x10\f\x12\x13\n" +
+	"\x0fcannot_call_uno\x10\r\x12\x14\n" +
+	"\x10catch_uno_failed\x10\x0e\x12\x19\n" +
+	"\x15must_answer_challenge\x10\x0f\x12\x10\n" +
+	"\fno_challenge\x10\x10\x12\x1b\n" +
+	"\x17must_play_drawn_or_pass\x10\x11\x12\x0f\n" +
+	"\vcannot_pass\x10\x12\x12\x14\n" +
+	"\x10replay_not_found\x10\x13\x12\x11\n" +
+	"\rnot_replaying\x10\x14\x12\x11\n" +
+	"\rnot_logged_in\x10\x15\x12\x10\n" +
+	"\flogin_failed\x10\x16\x12\x15\n" +
+	"\x11already_logged_in\x10\x17\x12\x15\n" +
+	"\x11god_view_disabled\x10\x18\x12\x11\n" +
+	"\rchat_too_fast\x10\x19\x12\x11\n" +
+	"\rchat_too_long\x10\x1a\x12\x11\n" +
+	"\rinvalid_emote\x10\x1b\x12\f\n" +
+	"\bnot_host\x10\x1c\x12\x11\n" +
+	"\rnot_all_ready\x10\x1d\x12\x0f\n" +
+	"\vnot_waiting\x10\x1e\x12\x14\n" +
+	"\x10game_not_started\x10\x1f\x12\x11\n" +
+	"\rstale_request\x10 \x12\x0e\n" +
+	"\n" +
+	"chat_empty\x10!\x12\x0f\n" +
+	"\vnot_playing\x10\"*\x82\x01\n" +
+	"\x05emote\x12\x0e\n" +
+	"\n" +
+	"emote_none\x10\x00\x12\x0f\n" +
+	"\vemote_hello\x10\x01\x12\x13\n" +
+	"\x0femote_good_game\x10\x02\x12\x10\n" +
+	"\femote_thanks\x10\x03\x12\x0e\n" +
+	"\n" +
+	"emote_oops\x10\x04\x12\x12\n" +
+	"\x0eemote_hurry_up\x10\x05\x12\r\n" +
+	"\temote_uno\x10\x06B\x10Z\x0eprotos/;protosb\x06proto3"
+
 var (
 	file_uno_proto_rawDescOnce sync.Once
-	file_uno_proto_rawDescData = file_uno_proto_rawDesc
+	file_uno_proto_rawDescData []byte
 )
 
 func file_uno_proto_rawDescGZIP() []byte {
 	file_uno_proto_rawDescOnce.Do(func() {
-		file_uno_proto_rawDescData = protoimpl.X.CompressGZIP(file_uno_proto_rawDescData)
+		file_uno_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_uno_proto_rawDesc), len(file_uno_proto_rawDesc)))
 	})
 	return file_uno_proto_rawDescData
 }
 
-var file_uno_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
-var file_uno_proto_goTypes = []interface{}{
-	(*UnoCard)(nil),             // 0: uno_card
-	(*InitToc)(nil),             // 1: init_toc
-	(*OtherAddHandCardToc)(nil), // 2: other_add_hand_card_toc
-	(*DrawCardToc)(nil),         // 3: draw_card_toc
-	(*NotifyTurnToc)(nil),       // 4: notify_turn_toc
-	(*SetDeckNumToc)(nil),       // 5: set_deck_num_toc
-	(*DiscardCardTos)(nil),      // 6: discard_card_tos
-	(*DiscardCardToc)(nil),      // 7: discard_card_toc
-	(*NotifyWinToc)(nil),        // 8: notify_win_toc
-	(*RestartGameTos)(nil),      // 9: restart_game_tos
+var file_uno_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_uno_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
+var file_uno_proto_goTypes = []any{
+	(GamePhase)(0),              // 0: game_phase
+	(ErrorCode)(0),              // 1: error_code
+	(Emote)(0),                  // 2: emote
+	(*UnoCard)(nil),             // 3: uno_card
+	(*PlayerIdentity)(nil),      // 4: player_identity
+	(*LoginTos)(nil),            // 5: login_tos
+	(*LoginToc)(nil),            // 6: login_toc
+	(*InitToc)(nil),             // 7: init_toc
+	(*SeatInfo)(nil),            // 8: seat_info
+	(*SeatInfoToc)(nil),         // 9: seat_info_toc
+	(*OtherAddHandCardToc)(nil), // 10: other_add_hand_card_toc
+	(*DrawCardToc)(nil),         // 11: draw_card_toc
+	(*NotifyTurnToc)(nil),       // 12: notify_turn_toc
+	(*SetDeckNumToc)(nil),       // 13: set_deck_num_toc
+	(*DiscardCardTos)(nil),      // 14: discard_card_tos
+	(*DiscardCardToc)(nil),      // 15: discard_card_toc
+	(*NotifyWinToc)(nil),        // 16: notify_win_toc
+	(*RestartGameTos)(nil),      // 17: restart_game_tos
+	(*PhaseToc)(nil),            // 18: phase_toc
+	(*RestartVoteToc)(nil),      // 19: restart_vote_toc
+	(*ReadyTos)(nil),            // 20: ready_tos
+	(*ReadyStateToc)(nil),       // 21: ready_state_toc
+	(*StartGameTos)(nil),        // 22: start_game_tos
+	(*RoomInfo)(nil),            // 23: room_info
+	(*RoomListToc)(nil),         // 24: room_list_toc
+	(*Rules)(nil),               // 25: rules
+	(*CreateRoomTos)(nil),       // 26: create_room_tos
+	(*JoinRoomTos)(nil),         // 27: join_room_tos
+	(*JoinRoomToc)(nil),         // 28: join_room_toc
+	(*LeaveRoomTos)(nil),        // 29: leave_room_tos
+	(*SpectateTos)(nil),         // 30: spectate_tos
+	(*SpectateToc)(nil),         // 31: spectate_toc
+	(*GodViewHand)(nil),         // 32: god_view_hand
+	(*GodViewToc)(nil),          // 33: god_view_toc
+	(*ReconnectTos)(nil),        // 34: reconnect_tos
+	(*GameStateToc)(nil),        // 35: game_state_toc
+	(*RequestStateTos)(nil),     // 36: request_state_tos
+	(*ErrorToc)(nil),            // 37: error_toc
+	(*PendingDrawToc)(nil),      // 38: pending_draw_toc
+	(*CallUnoTos)(nil),          // 39: call_uno_tos
+	(*UnoCalledToc)(nil),        // 40: uno_called_toc
+	(*CatchUnoTos)(nil),         // 41: catch_uno_tos
+	(*UnoCaughtToc)(nil),        // 42: uno_caught_toc
+	(*ChallengePlus4Tos)(nil),   // 43: challenge_plus4_tos
+	(*ChallengePlus4Toc)(nil),   // 44: challenge_plus4_toc
+	(*RevealHandToc)(nil),       // 45: reveal_hand_toc
+	(*DrawnCardToc)(nil),        // 46: drawn_card_toc
+	(*PassTos)(nil),             // 47: pass_tos
+	(*PlayerRoundResult)(nil),   // 48: player_round_result
+	(*RoundResultToc)(nil),      // 49: round_result_toc
+	(*MatchResultToc)(nil),      // 50: match_result_toc
+	(*ReplayListTos)(nil),       // 51: replay_list_tos
+	(*ReplayListToc)(nil),       // 52: replay_list_toc
+	(*ReplayTos)(nil),           // 53: replay_tos
+	(*ReplaySpeedTos)(nil),      // 54: replay_speed_tos
+	(*ReplayStartToc)(nil),      // 55: replay_start_toc
+	(*ReplayEventToc)(nil),      // 56: replay_event_toc
+	(*ReplayEndToc)(nil),        // 57: replay_end_toc
+	(*ChatTos)(nil),             // 58: chat_tos
+	(*EmoteTos)(nil),            // 59: emote_tos
+	(*ChatToc)(nil),             // 60: chat_toc
 }
 var file_uno_proto_depIdxs = []int32{
-	0, // 0: draw_card_toc.card:type_name -> uno_card
-	0, // 1: discard_card_toc.card:type_name -> uno_card
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4,  // 0: login_toc.identity:type_name -> player_identity
+	4,  // 1: init_toc.players:type_name -> player_identity
+	4,  // 2: seat_info.player:type_name -> player_identity
+	8,  // 3: seat_info_toc.seats:type_name -> seat_info
+	4,  // 4: other_add_hand_card_toc.player:type_name -> player_identity
+	3,  // 5: draw_card_toc.card:type_name -> uno_card
+	4,  // 6: notify_turn_toc.player:type_name -> player_identity
+	3,  // 7: discard_card_toc.card:type_name -> uno_card
+	4,  // 8: discard_card_toc.player:type_name -> player_identity
+	4,  // 9: notify_win_toc.player:type_name -> player_identity
+	0,  // 10: phase_toc.phase:type_name -> game_phase
+	4,  // 11: restart_vote_toc.player:type_name -> player_identity
+	4,  // 12: ready_state_toc.host:type_name -> player_identity
+	4,  // 13: ready_state_toc.ready_players:type_name -> player_identity
+	25, // 14: room_info.rules:type_name -> rules
+	23, // 15: room_list_toc.rooms:type_name -> room_info
+	25, // 16: create_room_tos.rules:type_name -> rules
+	3,  // 17: god_view_hand.cards:type_name -> uno_card
+	4,  // 18: god_view_hand.player:type_name -> player_identity
+	32, // 19: god_view_toc.hands:type_name -> god_view_hand
+	3,  // 20: game_state_toc.hand_card:type_name -> uno_card
+	3,  // 21: game_state_toc.last_card:type_name -> uno_card
+	3,  // 22: game_state_toc.drawn_card:type_name -> uno_card
+	4,  // 23: game_state_toc.players:type_name -> player_identity
+	0,  // 24: game_state_toc.phase:type_name -> game_phase
+	1,  // 25: error_toc.code:type_name -> error_code
+	4,  // 26: pending_draw_toc.player:type_name -> player_identity
+	4,  // 27: uno_called_toc.player:type_name -> player_identity
+	4,  // 28: uno_caught_toc.player:type_name -> player_identity
+	4,  // 29: uno_caught_toc.catcher:type_name -> player_identity
+	4,  // 30: challenge_plus4_toc.player:type_name -> player_identity
+	4,  // 31: challenge_plus4_toc.target:type_name -> player_identity
+	3,  // 32: reveal_hand_toc.cards:type_name -> uno_card
+	4,  // 33: reveal_hand_toc.player:type_name -> player_identity
+	3,  // 34: drawn_card_toc.card:type_name -> uno_card
+	3,  // 35: player_round_result.hand_card:type_name -> uno_card
+	4,  // 36: player_round_result.player:type_name -> player_identity
+	48, // 37: round_result_toc.results:type_name -> player_round_result
+	4,  // 38: round_result_toc.winner:type_name -> player_identity
+	4,  // 39: match_result_toc.winner:type_name -> player_identity
+	4,  // 40: match_result_toc.players:type_name -> player_identity
+	25, // 41: replay_start_toc.rules:type_name -> rules
+	4,  // 42: replay_start_toc.identities:type_name -> player_identity
+	3,  // 43: replay_event_toc.cards:type_name -> uno_card
+	2,  // 44: emote_tos.emote:type_name -> emote
+	4,  // 45: chat_toc.player:type_name -> player_identity
+	2,  // 46: chat_toc.emote:type_name -> emote
+	47, // [47:47] is the sub-list for method output_type
+	47, // [47:47] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_uno_proto_init() }
@@ -632,144 +4231,22 @@ func file_uno_proto_init() {
 	if File_uno_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_uno_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnoCard); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_uno_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InitToc); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_uno_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OtherAddHandCardToc); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_uno_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DrawCardToc); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_uno_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NotifyTurnToc); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_uno_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetDeckNumToc); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_uno_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DiscardCardTos); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_uno_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DiscardCardToc); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_uno_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NotifyWinToc); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_uno_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RestartGameTos); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_uno_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   10,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_uno_proto_rawDesc), len(file_uno_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   58,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_uno_proto_goTypes,
 		DependencyIndexes: file_uno_proto_depIdxs,
+		EnumInfos:         file_uno_proto_enumTypes,
 		MessageInfos:      file_uno_proto_msgTypes,
 	}.Build()
 	File_uno_proto = out.File
-	file_uno_proto_rawDesc = nil
 	file_uno_proto_goTypes = nil
 	file_uno_proto_depIdxs = nil
 }
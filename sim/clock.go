@@ -0,0 +1,116 @@
+package sim
+
+import (
+	"container/heap"
+	"github.com/CuteReimu/uno-server/game"
+	"github.com/davyxu/cellnet"
+	"time"
+)
+
+// clock 虚拟时钟。定时器不会真的等待，而是由advance按到期的先后顺序依次触发
+type clock struct {
+	now    time.Time
+	timers timerHeap
+	seq    int
+}
+
+type timer struct {
+	when    time.Time
+	seq     int // 同时到期的定时器按创建的顺序触发
+	f       func()
+	stopped bool
+}
+
+func (t *timer) Stop() bool {
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	return true
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) AfterFunc(d time.Duration, f func()) game.Timer {
+	c.seq++
+	t := &timer{when: c.now.Add(d), seq: c.seq, f: f}
+	heap.Push(&c.timers, t)
+	return t
+}
+
+// advance 把时间拨到下一个定时器到期的时候并触发它，没有定时器了就返回false
+func (c *clock) advance() bool {
+	for c.timers.Len() > 0 {
+		t := heap.Pop(&c.timers).(*timer)
+		if t.stopped {
+			continue
+		}
+		t.stopped = true
+		c.now = t.when
+		t.f()
+		return true
+	}
+	return false
+}
+
+type timerHeap []*timer
+
+func (h timerHeap) Len() int {
+	return len(h)
+}
+
+func (h timerHeap) Less(i, j int) bool {
+	if h[i].when.Equal(h[j].when) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].when.Before(h[j].when)
+}
+
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *timerHeap) Push(x any) {
+	*h = append(*h, x.(*timer))
+}
+
+func (h *timerHeap) Pop() any {
+	old := *h
+	t := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return t
+}
+
+// queue 同步的事件队列，投递的事件不会自己执行，而是由drain在当前goroutine中依次执行
+type queue struct {
+	events []func()
+}
+
+func (q *queue) StartLoop() cellnet.EventQueue {
+	return q
+}
+
+func (q *queue) StopLoop() cellnet.EventQueue {
+	return q
+}
+
+func (q *queue) Wait() {
+}
+
+func (q *queue) Post(callback func()) {
+	q.events = append(q.events, callback)
+}
+
+func (q *queue) EnableCapturePanic(bool) {
+}
+
+// drain 执行队列中所有的事件，包括执行过程中新投递的事件
+func (q *queue) drain() {
+	for len(q.events) > 0 {
+		callback := q.events[0]
+		q.events = q.events[1:]
+		callback()
+	}
+}
@@ -0,0 +1,197 @@
+// Package sim 在没有网络的情况下，用虚拟时钟快速进行大量只有机器人的对局，用来比较各种机器人策略的强弱
+package sim
+
+import (
+	"errors"
+	"github.com/CuteReimu/uno-server/game"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Config 模拟的配置
+type Config struct {
+	Games      int        // 对局数
+	Strategies []string   // 参加模拟的机器人策略，每个策略占一个座位
+	Rules      game.Rules // 房规
+	Parallel   int        // 同时进行的对局数，为0则使用CPU的核数
+	MaxMoves   int        // 每局最多的行动次数，超过了还没有人打完手牌，就算这局没有结束。为0表示不限制
+	Seed       int64      // 随机数种子，第i局使用Seed+i，为0则随机生成。不限时的策略用同样的种子可以得到同样的结果
+}
+
+// Entrant 一种参加模拟的策略的成绩
+type Entrant struct {
+	Strategy    string
+	Wins        int
+	WinRate     float64
+	WinRateLow  float64 // 胜率的95%置信区间
+	WinRateHigh float64
+	Points      int // 赢的局一共得到的分数
+}
+
+// Result 模拟的结果，只统计正常结束的对局
+type Result struct {
+	Seed         int64 // 实际使用的随机数种子
+	Games        int   // 正常结束的对局数
+	Unfinished   int   // 超过最多行动次数还没有结束的对局数
+	Entrants     []Entrant
+	AvgMoves     float64 // 平均每局的行动次数
+	AvgMovesLow  float64 // 平均行动次数的95%置信区间
+	AvgMovesHigh float64
+	AvgDuration  time.Duration // 平均每局的时长（虚拟时钟）
+}
+
+// gameResult 一局的结果
+type gameResult struct {
+	winner   int // 赢家在Config.Strategies中的下标，没有结束则为-1
+	points   int
+	moves    int
+	duration time.Duration
+}
+
+// Run 进行cfg.Games局只有机器人的对局。每一局都轮换座位，消除座位顺序对胜率的影响
+func Run(cfg Config) (*Result, error) {
+	if len(cfg.Strategies) < 2 {
+		return nil, errors.New("至少需要两个机器人策略")
+	}
+	for _, name := range cfg.Strategies {
+		if _, err := game.NewStrategy(name); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	parallel := cfg.Parallel
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+	results := make([]gameResult, cfg.Games)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				results[index] = playGame(index, cfg)
+			}
+		}()
+	}
+	for i := 0; i < cfg.Games; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return summarize(cfg, results), nil
+}
+
+// playGame 进行第index局，座位按index轮换
+func playGame(index int, cfg Config) gameResult {
+	total := len(cfg.Strategies)
+	moves := 0
+	robots := make([]game.Strategy, total)
+	for location := range robots {
+		strategy, _ := game.NewStrategy(cfg.Strategies[(index+location)%total])
+		robots[location] = &countingStrategy{Strategy: strategy, moves: &moves}
+	}
+	q := new(queue)
+	c := &clock{now: time.Unix(0, 0)}
+	g := game.NewGame(q, total, robots, cfg.Rules)
+	g.Clock = c
+	g.InitialSeed = cfg.Seed + int64(index)
+	g.Recording = false
+	g.Start()
+	q.drain()
+	for g.IsPlaying() && c.advance() {
+		q.drain()
+		if cfg.MaxMoves > 0 && moves > cfg.MaxMoves {
+			g.Stop()
+			return gameResult{winner: -1}
+		}
+	}
+	for location, player := range g.Players {
+		if player.CardCount() == 0 {
+			return gameResult{
+				winner:   (index + location) % total,
+				points:   player.Score(),
+				moves:    moves,
+				duration: c.now.Sub(time.Unix(0, 0)),
+			}
+		}
+	}
+	return gameResult{winner: -1}
+}
+
+// countingStrategy 记录行动次数的策略
+type countingStrategy struct {
+	game.Strategy
+	moves *int
+}
+
+func (s *countingStrategy) ChooseMove(v *game.View) game.Move {
+	*s.moves++
+	return s.Strategy.ChooseMove(v)
+}
+
+func summarize(cfg Config, results []gameResult) *Result {
+	result := &Result{Seed: cfg.Seed, Entrants: make([]Entrant, len(cfg.Strategies))}
+	for i, name := range cfg.Strategies {
+		result.Entrants[i].Strategy = name
+	}
+	var moves []float64
+	var duration time.Duration
+	for _, r := range results {
+		if r.winner < 0 {
+			result.Unfinished++
+			continue
+		}
+		result.Games++
+		result.Entrants[r.winner].Wins++
+		result.Entrants[r.winner].Points += r.points
+		moves = append(moves, float64(r.moves))
+		duration += r.duration
+	}
+	if result.Games == 0 {
+		return result
+	}
+	for i := range result.Entrants {
+		e := &result.Entrants[i]
+		e.WinRate = float64(e.Wins) / float64(result.Games)
+		e.WinRateLow, e.WinRateHigh = wilsonInterval(e.Wins, result.Games)
+	}
+	result.AvgMoves, result.AvgMovesLow, result.AvgMovesHigh = meanInterval(moves)
+	result.AvgDuration = duration / time.Duration(result.Games)
+	return result
+}
+
+// z 95%置信区间对应的正态分布分位数
+const z = 1.96
+
+// wilsonInterval 胜率的Wilson置信区间，在对局数少或者胜率接近0和1的时候也比较准
+func wilsonInterval(wins, n int) (low, high float64) {
+	p := float64(wins) / float64(n)
+	nf := float64(n)
+	center := (p + z*z/(2*nf)) / (1 + z*z/nf)
+	margin := z * math.Sqrt(p*(1-p)/nf+z*z/(4*nf*nf)) / (1 + z*z/nf)
+	return center - margin, center + margin
+}
+
+// meanInterval 平均值和它的置信区间
+func meanInterval(values []float64) (mean, low, high float64) {
+	n := float64(len(values))
+	for _, v := range values {
+		mean += v
+	}
+	mean /= n
+	if len(values) < 2 {
+		return mean, mean, mean
+	}
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	margin := z * math.Sqrt(variance/(n-1)/n)
+	return mean, mean - margin, mean + margin
+}
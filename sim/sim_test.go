@@ -0,0 +1,147 @@
+package sim
+
+import (
+	"github.com/CuteReimu/uno-server/config"
+	"github.com/CuteReimu/uno-server/game"
+	"github.com/CuteReimu/uno-server/utils"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	utils.SetLogLevel(slog.LevelError + 1)
+	config.GlobalConfig.SetConfigFile(filepath.Join("..", "config.yaml"))
+	if err := config.GlobalConfig.ReadInConfig(); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+func TestRunConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		ok   bool
+	}{
+		{"只有一个策略", Config{Games: 1, Strategies: []string{"easy"}}, false},
+		{"不存在的策略", Config{Games: 1, Strategies: []string{"easy", "foo"}}, false},
+		{"两个策略", Config{Games: 1, Strategies: []string{"easy", "hard"}, Seed: 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Run(tt.cfg); (err == nil) != tt.ok {
+				t.Errorf("Run() err = %v", err)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"标准规则", Config{Games: 30, Strategies: []string{"easy", "normal", "hard"}, Seed: 1}},
+		{"各种房规", Config{Games: 30, Strategies: []string{"hard", "easy"}, Seed: 2, Rules: game.Rules{SevenZero: true, JumpIn: true, StackPlus2: true, StackPlus4: true, ChallengePlus4: true}}},
+		{"行动次数太少", Config{Games: 10, Strategies: []string{"easy", "easy"}, Seed: 3, MaxMoves: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []*Result
+			for _, parallel := range []int{1, 4} {
+				cfg := tt.cfg
+				cfg.Parallel = parallel
+				result, err := Run(cfg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				results = append(results, result)
+			}
+			// 每一局的种子是固定的，同时进行几局不影响结果
+			if !reflect.DeepEqual(results[0], results[1]) {
+				t.Fatalf("同样的种子结果不一样：\n%+v\n%+v", results[0], results[1])
+			}
+			r := results[0]
+			if r.Games+r.Unfinished != tt.cfg.Games {
+				t.Errorf("结束%d局，没有结束%d局，一共应该是%d局", r.Games, r.Unfinished, tt.cfg.Games)
+			}
+			wins := 0
+			for _, e := range r.Entrants {
+				wins += e.Wins
+				if e.Wins > 0 && e.Points <= 0 {
+					t.Errorf("%s赢了%d局，却没有得分", e.Strategy, e.Wins)
+				}
+			}
+			if wins != r.Games {
+				t.Errorf("一共赢了%d局，应该是%d局", wins, r.Games)
+			}
+			if tt.cfg.MaxMoves > 0 && r.Unfinished != tt.cfg.Games {
+				t.Errorf("每局最多%d次行动，应该都没有结束，实际结束了%d局", tt.cfg.MaxMoves, r.Games)
+			}
+		})
+	}
+}
+
+func TestWilsonInterval(t *testing.T) {
+	tests := []struct {
+		wins, n   int
+		low, high float64
+	}{
+		{0, 10, 0, 0.2775},
+		{5, 10, 0.2366, 0.7634},
+		{10, 10, 0.7225, 1},
+		{50, 100, 0.4038, 0.5962},
+	}
+	for _, tt := range tests {
+		low, high := wilsonInterval(tt.wins, tt.n)
+		if math.Abs(low-tt.low) > 1e-4 || math.Abs(high-tt.high) > 1e-4 {
+			t.Errorf("wilsonInterval(%d, %d) = [%.4f, %.4f]，应该是[%.4f, %.4f]", tt.wins, tt.n, low, high, tt.low, tt.high)
+		}
+	}
+}
+
+func TestMeanInterval(t *testing.T) {
+	tests := []struct {
+		values          []float64
+		mean, low, high float64
+	}{
+		{[]float64{3}, 3, 3, 3},
+		{[]float64{2, 2, 2}, 2, 2, 2},
+		{[]float64{1, 3}, 2, 2 - 1.96, 2 + 1.96},
+	}
+	for _, tt := range tests {
+		mean, low, high := meanInterval(tt.values)
+		if math.Abs(mean-tt.mean) > 1e-9 || math.Abs(low-tt.low) > 1e-9 || math.Abs(high-tt.high) > 1e-9 {
+			t.Errorf("meanInterval(%v) = %v [%v, %v]，应该是%v [%v, %v]", tt.values, mean, low, high, tt.mean, tt.low, tt.high)
+		}
+	}
+}
+
+func TestClock(t *testing.T) {
+	c := &clock{now: time.Unix(0, 0)}
+	var fired []int
+	fire := func(i int) func() {
+		return func() { fired = append(fired, i) }
+	}
+	c.AfterFunc(2*time.Second, fire(1))
+	c.AfterFunc(time.Second, fire(2))
+	c.AfterFunc(2*time.Second, fire(3)) // 和1同时到期，在1之后触发
+	stopped := c.AfterFunc(time.Second, fire(4))
+	if !stopped.Stop() || stopped.Stop() {
+		t.Error("只有第一次Stop应该返回true")
+	}
+	for c.advance() {
+	}
+	if !slices.Equal(fired, []int{2, 1, 3}) {
+		t.Errorf("定时器触发的顺序是%v，应该是[2 1 3]", fired)
+	}
+	if !c.Now().Equal(time.Unix(2, 0)) {
+		t.Errorf("时间应该拨到了第2秒，实际是%v", c.Now())
+	}
+}
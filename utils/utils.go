@@ -8,10 +8,17 @@ import (
 	"time"
 )
 
+var logLevel = new(slog.LevelVar)
+
 func GetLogger(module string) *slog.Logger {
 	return slog.With("module", module)
 }
 
+// SetLogLevel 设置日志的级别，默认是Info
+func SetLogLevel(level slog.Level) {
+	logLevel.Set(level)
+}
+
 func init() {
 	writerError, err := rotatelogs.New(
 		path.Join("logs", "error-%Y-%m-%d.log"),
@@ -25,7 +32,7 @@ func init() {
 
 	slog.SetDefault(slog.New(slog.NewTextHandler(writerError, &slog.HandlerOptions{
 		AddSource: true,
-		Level:     slog.LevelInfo,
+		Level:     logLevel,
 		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 			switch a.Key {
 			case slog.TimeKey: